@@ -2,6 +2,8 @@ package builders
 
 import (
 	"testing"
+
+	"github.com/adil-faiyaz98/go-builder-kit/models"
 )
 
 func TestBuilders(t *testing.T) {
@@ -76,3 +78,105 @@ func TestBuilders(t *testing.T) {
 		t.Errorf("Expected 1 course, got %d", len(person.Education.Courses))
 	}
 }
+
+func TestBuildReturnsIndependentObjects(t *testing.T) {
+	personBuilder := NewPersonBuilder().
+		WithName("John Doe").
+		WithAddress(NewAddressBuilder().WithCity("San Francisco")).
+		AddFriend(NewPersonBuilder().WithName("Jane Doe"))
+
+	first := personBuilder.BuildPtr()
+	second := personBuilder.BuildPtr()
+	if first == second || first.Address == second.Address || first.Friends[0] == second.Friends[0] {
+		t.Fatal("Expected every BuildPtr to return a new copy of the person")
+	}
+
+	// Changing one object leaves the other and the builder alone
+	first.Address.City = "Oakland"
+	first.Friends[0].Name = "Jim Doe"
+	if second.Address.City != "San Francisco" || second.Friends[0].Name != "Jane Doe" {
+		t.Errorf("Expected the second person to be unchanged, got %s and %s", second.Address.City, second.Friends[0].Name)
+	}
+
+	// and so does changing the builder after a build
+	value := personBuilder.Build()
+	personBuilder.WithName("Changed").AddFriend(NewPersonBuilder())
+	if value.Name != "John Doe" || len(value.Friends) != 1 || value.Address == first.Address {
+		t.Errorf("Expected the built person to be independent of the builder, got %+v", value)
+	}
+}
+
+func TestBuildBuilderCycle(t *testing.T) {
+	john := NewPersonBuilder().WithName("John Doe")
+	jane := NewPersonBuilder().WithName("Jane Doe").AddFriend(john)
+	john.AddFriend(jane)
+
+	person := john.BuildPtr()
+	friend := person.Friends[0]
+	if friend.Name != "Jane Doe" || len(friend.Friends) != 1 || friend.Friends[0] != person {
+		t.Errorf("Expected the cycle between the builders to become a cycle between the people, got %+v", friend)
+	}
+}
+
+func TestCloneBuilderIsIndependent(t *testing.T) {
+	address := NewAddressBuilder().WithCity("San Francisco")
+	original := NewPersonBuilder().
+		WithName("John Doe").
+		WithAddress(address).
+		WithFriends(make([]*PersonBuilder, 0, 4)).
+		AddFriend(NewPersonBuilder().WithName("Jane Doe"))
+
+	// The friends slice has spare capacity, which a shallow clone would share
+	clone := original.CloneBuilder()
+	clone.AddFriend(NewPersonBuilder().WithName("Jim Doe"))
+	address.WithCity("Oakland")
+
+	person := original.BuildPtr()
+	if len(person.Friends) != 1 || person.Address.City != "Oakland" {
+		t.Errorf("Expected the original to keep one friend and its address builder, got %d and %s", len(person.Friends), person.Address.City)
+	}
+
+	cloned := clone.BuildPtr()
+	if len(cloned.Friends) != 2 || cloned.Friends[1].Name != "Jim Doe" || cloned.Address.City != "San Francisco" {
+		t.Errorf("Expected the clone to have two friends and its own address builder, got %d and %s", len(cloned.Friends), cloned.Address.City)
+	}
+}
+
+func TestModelDeepCopy(t *testing.T) {
+	supervisor := &models.Person{Name: "Jane Doe"}
+	original := &models.Person{
+		Name:       "John Doe",
+		Friends:    make([]*models.Person, 1, 4),
+		Employment: &models.Employment{Supervisor: supervisor},
+	}
+	original.Friends[0] = &models.Person{Name: "Jim Doe"}
+
+	copied := original.DeepCopy()
+	copied.Friends[0].Name = "Changed"
+	copied.Friends = append(copied.Friends, &models.Person{Name: "Added"})
+	copied.Employment.Supervisor.(*models.Person).Name = "Changed"
+
+	if original.Friends[0].Name != "Jim Doe" || original.Friends[:2][1] != nil {
+		t.Errorf("Expected the original friends to be unchanged, got %+v", original.Friends[:2])
+	}
+	if copied.Employment.Supervisor == supervisor || supervisor.Name != "Jane Doe" {
+		t.Errorf("Expected the supervisor to be copied, got %+v", supervisor)
+	}
+}
+
+func TestModelDeepCopyCycle(t *testing.T) {
+	john := NewPersonBuilder().WithName("John Doe")
+	jane := NewPersonBuilder().WithName("Jane Doe").AddFriend(john)
+	john.AddFriend(jane)
+	person := john.BuildPtr()
+	person.Employment = &models.Employment{Supervisor: person.Friends[0]}
+
+	copied := person.DeepCopy()
+	friend := copied.Friends[0]
+	if friend == person.Friends[0] || friend.Friends[0] != copied {
+		t.Errorf("Expected the cycle to be copied as a cycle, got %+v", friend)
+	}
+	if copied.Employment.Supervisor != friend {
+		t.Errorf("Expected the supervisor and the friend to stay one person")
+	}
+}
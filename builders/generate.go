@@ -0,0 +1,5 @@
+package builders
+
+// Builders are generated for the models the examples and tests use; the finance
+// and employment models are left out.
+//go:generate go run ../cmd/builder-gen -input ../models -output . -package builders -exclude Account,Bank,Bond,Company,Employment,Investment,PerformanceRecord,Portfolio
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/adil-faiyaz98/go-builder-kit/pkg/generator"
 )
@@ -15,72 +17,172 @@ func main() {
 	outputDir := flag.String("output", "", "Output directory for generated builder files")
 	packageName := flag.String("package", "builders", "Package name for generated builder files")
 	modelsPackage := flag.String("models-package", "", "Package path for the models (e.g., github.com/user/repo/models)")
+	style := flag.String("style", generator.OutputStyleBuilder, "Style of generated code: builder, options, step or deepcopy")
 	recursive := flag.Bool("recursive", false, "Recursively process all Go files in the input directory")
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
+	typeFilter := flag.String("types", "", "Comma-separated type name patterns (globs or /regexps/) to generate builders for")
+	exclude := flag.String("exclude", "", "Comma-separated type name patterns (globs or /regexps/) to skip")
+	includeTests := flag.Bool("include-tests", false, "Generate builders for structs in _test.go files")
+	includeGenerated := flag.Bool("include-generated", false, "Generate builders for structs in generated files")
+	templateDir := flag.String("template-dir", "", "Directory of .tmpl files overriding the built-in templates or adding extra per-struct templates")
+	check := flag.Bool("check", false, "Report generated files that are missing or out of date instead of writing them")
+	samePackage := flag.Bool("same-package", false, "Generate builders into the models package (output defaults to the input directory)")
+	testFiles := flag.Bool("test-files", false, "Name generated files *_test.go so that builders are only compiled into tests")
+	configFile := flag.String("config", "", "Configuration file with input/output mappings (default: builder-gen.yaml, .yml or .json in the current directory)")
 
 	// Parse command-line flags
 	flag.Parse()
 
+	// Flags that were set explicitly override the configuration file
+	var flags generator.Settings
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "package":
+			flags.PackageName = *packageName
+		case "models-package":
+			flags.ModelsPackage = *modelsPackage
+		case "style":
+			flags.Style = *style
+		case "recursive":
+			flags.Recursive = recursive
+		case "types":
+			flags.Types = splitList(*typeFilter)
+		case "exclude":
+			flags.Exclude = splitList(*exclude)
+		case "include-tests":
+			flags.IncludeTests = includeTests
+		case "include-generated":
+			flags.IncludeGenerated = includeGenerated
+		case "template-dir":
+			flags.TemplateDir = *templateDir
+		case "same-package":
+			flags.SamePackage = samePackage
+		case "test-files":
+			flags.TestFiles = testFiles
+		}
+	})
+
+	// Load the configuration file, if any
+	config := &generator.Config{}
+	path := *configFile
+	if path == "" && *inputFile == "" {
+		path = generator.FindConfig(".")
+	}
+	if path != "" {
+		var err error
+		if config, err = generator.LoadConfig(path); err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// An input on the command line replaces the mappings of the configuration
+	mappings := config.Mappings
+	if *inputFile != "" || *outputDir != "" {
+		mappings = []generator.Mapping{{Input: *inputFile, Output: *outputDir}}
+	}
+
 	// Validate input
-	if *inputFile == "" {
+	if len(mappings) == 0 {
 		fmt.Println("Error: input file or directory is required")
 		flag.Usage()
 		os.Exit(1)
 	}
-
-	if *outputDir == "" {
-		fmt.Println("Error: output directory is required")
-		flag.Usage()
-		os.Exit(1)
+	for i, m := range mappings {
+		if m.Input == "" {
+			fmt.Println("Error: input file or directory is required")
+			flag.Usage()
+			os.Exit(1)
+		}
+		// Builders in the models package are written next to the models
+		if m.Output == "" && config.Settings.Merge(m.Settings).Merge(flags).IsSamePackage() {
+			mappings[i].Output = inputDir(m.Input)
+			continue
+		}
+		if m.Output == "" {
+			fmt.Println("Error: output directory is required")
+			flag.Usage()
+			os.Exit(1)
+		}
 	}
 
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(*outputDir, 0755); err != nil {
-		fmt.Printf("Error creating output directory: %v\n", err)
-		os.Exit(1)
+	outOfDate := false
+	for _, m := range mappings {
+		settings := config.Settings.Merge(m.Settings).Merge(flags)
+		err := run(m.Input, m.Output, settings, *verbose, *check)
+		if errors.Is(err, generator.ErrOutOfDate) {
+			fmt.Printf("%v; rerun builder-gen\n", err)
+			outOfDate = true
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error generating builders for %s: %v\n", m.Input, err)
+			os.Exit(1)
+		}
 	}
 
-	// Create generator options
-	opts := generator.Options{
-		PackageName:   *packageName,
-		ModelsPackage: *modelsPackage,
-		Verbose:       *verbose,
+	if *check {
+		if outOfDate {
+			os.Exit(1)
+		}
+		fmt.Println("Generated builders are up to date")
+		return
 	}
 
-	// Process input
-	fileInfo, err := os.Stat(*inputFile)
+	fmt.Println("Builder generation completed successfully!")
+}
+
+// run generates builders for a single input and writes or checks them
+func run(input, output string, settings generator.Settings, verbose, check bool) error {
+	opts, err := settings.Options()
 	if err != nil {
-		fmt.Printf("Error accessing input: %v\n", err)
-		os.Exit(1)
+		return err
+	}
+	opts.Verbose = verbose
+	opts.OutputDir = output
+	if opts.SamePackage && settings.IsRecursive() {
+		return fmt.Errorf("same-package output cannot be combined with recursive input")
 	}
 
-	if fileInfo.IsDir() {
-		// Process directory
-		if *recursive {
-			err = processDirectory(*inputFile, *outputDir, opts)
-		} else {
-			err = processFiles([]string{*inputFile}, *outputDir, opts)
-		}
-	} else {
-		// Process single file
-		err = processFiles([]string{*inputFile}, *outputDir, opts)
+	files, err := collectFiles(input, settings.IsRecursive())
+	if err != nil {
+		return err
 	}
 
+	result, err := generator.NewGenerator(opts).Generate(generator.Input{Files: files})
 	if err != nil {
-		fmt.Printf("Error generating builders: %v\n", err)
-		os.Exit(1)
+		return err
+	}
+	if !verbose {
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
 	}
 
-	fmt.Println("Builder generation completed successfully!")
+	if check {
+		return generator.DiffSink{Dir: output}.Write(result)
+	}
+	return generator.DirSink{Dir: output}.Write(result)
 }
 
-func processDirectory(inputDir, outputDir string, opts generator.Options) error {
-	var files []string
+// collectFiles returns the Go files of input, which is a file or a directory
+func collectFiles(input string, recursive bool) ([]string, error) {
+	info, err := os.Stat(input)
+	if err != nil {
+		return nil, fmt.Errorf("error accessing input: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{input}, nil
+	}
 
-	err := filepath.Walk(inputDir, func(path string, info os.FileInfo, err error) error {
+	var files []string
+	err = filepath.Walk(input, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if info.IsDir() && path != input && !recursive {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() && filepath.Ext(path) == ".go" {
 			files = append(files, path)
 		}
@@ -88,48 +190,28 @@ func processDirectory(inputDir, outputDir string, opts generator.Options) error
 	})
 
 	if err != nil {
-		return fmt.Errorf("error walking directory: %w", err)
+		return nil, fmt.Errorf("error walking directory: %w", err)
 	}
 
-	return processFiles(files, outputDir, opts)
+	return files, nil
 }
 
-func processFiles(files []string, outputDir string, opts generator.Options) error {
-	gen := generator.NewGenerator(opts)
-
-	// Write utility file first
-	utilFile := filepath.Join(outputDir, "builder_util.go")
-	utilCode := fmt.Sprintf("package %s\n\n%s", opts.PackageName, generator.UtilTemplate)
-	if err := os.WriteFile(utilFile, []byte(utilCode), 0644); err != nil {
-		return fmt.Errorf("error writing utility file %s: %w", utilFile, err)
-	}
-
-	if opts.Verbose {
-		fmt.Printf("Generated utility file: %s\n", utilFile)
-	}
-
-	// Write builder registry file
-	registryFile := filepath.Join(outputDir, "builder_registry.go")
-	registryCode := fmt.Sprintf("package %s\n\n%s", opts.PackageName, generator.RegistryTemplate)
-	if err := os.WriteFile(registryFile, []byte(registryCode), 0644); err != nil {
-		return fmt.Errorf("error writing registry file %s: %w", registryFile, err)
-	}
-
-	if opts.Verbose {
-		fmt.Printf("Generated registry file: %s\n", registryFile)
+// inputDir returns the directory of input, which is a file or a directory
+func inputDir(input string) string {
+	if info, err := os.Stat(input); err == nil && !info.IsDir() {
+		return filepath.Dir(input)
 	}
+	return input
+}
 
-	for _, file := range files {
-		if opts.Verbose {
-			fmt.Printf("Processing file: %s\n", file)
-		}
-
-		// Process the file
-		err := gen.ProcessFile(file, outputDir)
-		if err != nil {
-			return fmt.Errorf("error processing file %s: %w", file, err)
+// splitList splits a comma-separated flag value, dropping empty items. The
+// result is never nil, so that an explicit -types= clears the configured filter.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
-
-	return nil
+	return items
 }
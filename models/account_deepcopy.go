@@ -0,0 +1,80 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Account into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Account) DeepCopyInto(out *Account) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Account
+func (in *Account) DeepCopy() *Account {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Account with c, which copies models
+// shared with other copies made by c only once
+func (in *Account) DeepCopyWith(c *builder.Copier) *Account {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Account)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Account into out with c
+func (in *Account) deepCopyInto(c *builder.Copier, out *Account) {
+	*out = *in
+	if in.Transactions != nil {
+		out.Transactions = make([]any, len(in.Transactions))
+		for i0 := range in.Transactions {
+			switch v1 := in.Transactions[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Transactions[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Transactions[i0] = v1.deepCopyValue(c)
+			default:
+				out.Transactions[i0] = in.Transactions[i0]
+			}
+		}
+	}
+	if in.CoOwners != nil {
+		out.CoOwners = make([]any, len(in.CoOwners))
+		for i0 := range in.CoOwners {
+			switch v1 := in.CoOwners[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.CoOwners[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.CoOwners[i0] = v1.deepCopyValue(c)
+			default:
+				out.CoOwners[i0] = in.CoOwners[i0]
+			}
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Account
+func (in *Account) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Account
+func (in Account) deepCopyValue(c *builder.Copier) any {
+	var out Account
+	in.deepCopyInto(c, &out)
+	return out
+}
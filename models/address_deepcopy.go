@@ -0,0 +1,55 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Address into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Address) DeepCopyInto(out *Address) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Address
+func (in *Address) DeepCopy() *Address {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Address with c, which copies models
+// shared with other copies made by c only once
+func (in *Address) DeepCopyWith(c *builder.Copier) *Address {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Address)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Address into out with c
+func (in *Address) deepCopyInto(c *builder.Copier, out *Address) {
+	*out = *in
+	out.Coordinates = in.Coordinates.DeepCopyWith(c)
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Address
+func (in *Address) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Address
+func (in Address) deepCopyValue(c *builder.Copier) any {
+	var out Address
+	in.deepCopyInto(c, &out)
+	return out
+}
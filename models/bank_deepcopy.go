@@ -0,0 +1,108 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Bank into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Bank) DeepCopyInto(out *Bank) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Bank
+func (in *Bank) DeepCopy() *Bank {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Bank with c, which copies models
+// shared with other copies made by c only once
+func (in *Bank) DeepCopyWith(c *builder.Copier) *Bank {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Bank)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Bank into out with c
+func (in *Bank) deepCopyInto(c *builder.Copier, out *Bank) {
+	*out = *in
+	out.Address = in.Address.DeepCopyWith(c)
+	if in.Accounts != nil {
+		out.Accounts = make([]*Account, len(in.Accounts))
+		for i0 := range in.Accounts {
+			out.Accounts[i0] = in.Accounts[i0].DeepCopyWith(c)
+		}
+	}
+	if in.Stocks != nil {
+		out.Stocks = make([]any, len(in.Stocks))
+		for i0 := range in.Stocks {
+			switch v1 := in.Stocks[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Stocks[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Stocks[i0] = v1.deepCopyValue(c)
+			default:
+				out.Stocks[i0] = in.Stocks[i0]
+			}
+		}
+	}
+	if in.Loans != nil {
+		out.Loans = make([]any, len(in.Loans))
+		for i0 := range in.Loans {
+			switch v1 := in.Loans[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Loans[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Loans[i0] = v1.deepCopyValue(c)
+			default:
+				out.Loans[i0] = in.Loans[i0]
+			}
+		}
+	}
+	if in.Investments != nil {
+		out.Investments = make([]any, len(in.Investments))
+		for i0 := range in.Investments {
+			switch v1 := in.Investments[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Investments[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Investments[i0] = v1.deepCopyValue(c)
+			default:
+				out.Investments[i0] = in.Investments[i0]
+			}
+		}
+	}
+	switch v0 := in.Advisor.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Advisor = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Advisor = v0.deepCopyValue(c)
+	default:
+		out.Advisor = in.Advisor
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Bank
+func (in *Bank) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Bank
+func (in Bank) deepCopyValue(c *builder.Copier) any {
+	var out Bank
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,62 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Company into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Company) DeepCopyInto(out *Company) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Company
+func (in *Company) DeepCopy() *Company {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Company with c, which copies models
+// shared with other copies made by c only once
+func (in *Company) DeepCopyWith(c *builder.Copier) *Company {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Company)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Company into out with c
+func (in *Company) deepCopyInto(c *builder.Copier, out *Company) {
+	*out = *in
+	out.Address = in.Address.DeepCopyWith(c)
+	out.Location = in.Location.DeepCopyWith(c)
+	if in.Departments != nil {
+		out.Departments = make([]*Department, len(in.Departments))
+		for i0 := range in.Departments {
+			out.Departments[i0] = in.Departments[i0].DeepCopyWith(c)
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Company
+func (in *Company) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Company
+func (in Company) deepCopyValue(c *builder.Copier) any {
+	var out Company
+	in.deepCopyInto(c, &out)
+	return out
+}
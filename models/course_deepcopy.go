@@ -0,0 +1,54 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Course into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Course) DeepCopyInto(out *Course) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Course
+func (in *Course) DeepCopy() *Course {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Course with c, which copies models
+// shared with other copies made by c only once
+func (in *Course) DeepCopyWith(c *builder.Copier) *Course {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Course)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Course into out with c
+func (in *Course) deepCopyInto(c *builder.Copier, out *Course) {
+	*out = *in
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Course
+func (in *Course) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Course
+func (in Course) deepCopyValue(c *builder.Copier) any {
+	var out Course
+	in.deepCopyInto(c, &out)
+	return out
+}
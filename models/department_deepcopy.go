@@ -0,0 +1,89 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Department into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Department) DeepCopyInto(out *Department) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Department
+func (in *Department) DeepCopy() *Department {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Department with c, which copies models
+// shared with other copies made by c only once
+func (in *Department) DeepCopyWith(c *builder.Copier) *Department {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Department)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Department into out with c
+func (in *Department) deepCopyInto(c *builder.Copier, out *Department) {
+	*out = *in
+	switch v0 := in.Manager.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Manager = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Manager = v0.deepCopyValue(c)
+	default:
+		out.Manager = in.Manager
+	}
+	if in.Employees != nil {
+		out.Employees = make([]interface{}, len(in.Employees))
+		for i0 := range in.Employees {
+			switch v1 := in.Employees[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Employees[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Employees[i0] = v1.deepCopyValue(c)
+			default:
+				out.Employees[i0] = in.Employees[i0]
+			}
+		}
+	}
+	if in.Projects != nil {
+		out.Projects = make([]interface{}, len(in.Projects))
+		for i0 := range in.Projects {
+			switch v1 := in.Projects[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Projects[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Projects[i0] = v1.deepCopyValue(c)
+			default:
+				out.Projects[i0] = in.Projects[i0]
+			}
+		}
+	}
+	out.Location = in.Location.DeepCopyWith(c)
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Department
+func (in *Department) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Department
+func (in Department) deepCopyValue(c *builder.Copier) any {
+	var out Department
+	in.deepCopyInto(c, &out)
+	return out
+}
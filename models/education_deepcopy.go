@@ -0,0 +1,69 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Education into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Education) DeepCopyInto(out *Education) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Education
+func (in *Education) DeepCopy() *Education {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Education with c, which copies models
+// shared with other copies made by c only once
+func (in *Education) DeepCopyWith(c *builder.Copier) *Education {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Education)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Education into out with c
+func (in *Education) deepCopyInto(c *builder.Copier, out *Education) {
+	*out = *in
+	out.Location = in.Location.DeepCopyWith(c)
+	if in.Honors != nil {
+		out.Honors = make([]string, len(in.Honors))
+		copy(out.Honors, in.Honors)
+	}
+	if in.Courses != nil {
+		out.Courses = make([]*Course, len(in.Courses))
+		for i0 := range in.Courses {
+			out.Courses[i0] = in.Courses[i0].DeepCopyWith(c)
+		}
+	}
+	if in.Activities != nil {
+		out.Activities = make([]string, len(in.Activities))
+		copy(out.Activities, in.Activities)
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Education
+func (in *Education) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Education
+func (in Education) deepCopyValue(c *builder.Copier) any {
+	var out Education
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,76 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Employment into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Employment) DeepCopyInto(out *Employment) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Employment
+func (in *Employment) DeepCopy() *Employment {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Employment with c, which copies models
+// shared with other copies made by c only once
+func (in *Employment) DeepCopyWith(c *builder.Copier) *Employment {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Employment)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Employment into out with c
+func (in *Employment) deepCopyInto(c *builder.Copier, out *Employment) {
+	*out = *in
+	out.Company = in.Company.DeepCopyWith(c)
+	switch v0 := in.Supervisor.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Supervisor = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Supervisor = v0.deepCopyValue(c)
+	default:
+		out.Supervisor = in.Supervisor
+	}
+	if in.Subordinates != nil {
+		out.Subordinates = make([]any, len(in.Subordinates))
+		for i0 := range in.Subordinates {
+			switch v1 := in.Subordinates[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Subordinates[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Subordinates[i0] = v1.deepCopyValue(c)
+			default:
+				out.Subordinates[i0] = in.Subordinates[i0]
+			}
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Employment
+func (in *Employment) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Employment
+func (in Employment) deepCopyValue(c *builder.Copier) any {
+	var out Employment
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,55 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the FamilyMember into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *FamilyMember) DeepCopyInto(out *FamilyMember) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the FamilyMember
+func (in *FamilyMember) DeepCopy() *FamilyMember {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the FamilyMember with c, which copies models
+// shared with other copies made by c only once
+func (in *FamilyMember) DeepCopyWith(c *builder.Copier) *FamilyMember {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(FamilyMember)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the FamilyMember into out with c
+func (in *FamilyMember) deepCopyInto(c *builder.Copier, out *FamilyMember) {
+	*out = *in
+	out.Person = in.Person.DeepCopyWith(c)
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *FamilyMember
+func (in *FamilyMember) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a FamilyMember
+func (in FamilyMember) deepCopyValue(c *builder.Copier) any {
+	var out FamilyMember
+	in.deepCopyInto(c, &out)
+	return out
+}
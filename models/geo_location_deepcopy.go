@@ -0,0 +1,54 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the GeoLocation into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *GeoLocation) DeepCopyInto(out *GeoLocation) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the GeoLocation
+func (in *GeoLocation) DeepCopy() *GeoLocation {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the GeoLocation with c, which copies models
+// shared with other copies made by c only once
+func (in *GeoLocation) DeepCopyWith(c *builder.Copier) *GeoLocation {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(GeoLocation)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the GeoLocation into out with c
+func (in *GeoLocation) deepCopyInto(c *builder.Copier, out *GeoLocation) {
+	*out = *in
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *GeoLocation
+func (in *GeoLocation) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a GeoLocation
+func (in GeoLocation) deepCopyValue(c *builder.Copier) any {
+	var out GeoLocation
+	in.deepCopyInto(c, &out)
+	return out
+}
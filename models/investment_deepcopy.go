@@ -0,0 +1,61 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Investment into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Investment) DeepCopyInto(out *Investment) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Investment
+func (in *Investment) DeepCopy() *Investment {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Investment with c, which copies models
+// shared with other copies made by c only once
+func (in *Investment) DeepCopyWith(c *builder.Copier) *Investment {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Investment)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Investment into out with c
+func (in *Investment) deepCopyInto(c *builder.Copier, out *Investment) {
+	*out = *in
+	out.Portfolio = in.Portfolio.DeepCopyWith(c)
+	if in.Performance != nil {
+		out.Performance = make([]*PerformanceRecord, len(in.Performance))
+		for i0 := range in.Performance {
+			out.Performance[i0] = in.Performance[i0].DeepCopyWith(c)
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Investment
+func (in *Investment) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Investment
+func (in Investment) deepCopyValue(c *builder.Copier) any {
+	var out Investment
+	in.deepCopyInto(c, &out)
+	return out
+}
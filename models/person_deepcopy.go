@@ -0,0 +1,101 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Person into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Person) DeepCopyInto(out *Person) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Person
+func (in *Person) DeepCopy() *Person {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Person with c, which copies models
+// shared with other copies made by c only once
+func (in *Person) DeepCopyWith(c *builder.Copier) *Person {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Person)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Person into out with c
+func (in *Person) deepCopyInto(c *builder.Copier, out *Person) {
+	*out = *in
+	out.Address = in.Address.DeepCopyWith(c)
+	out.Education = in.Education.DeepCopyWith(c)
+	switch v0 := in.Profile.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Profile = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Profile = v0.deepCopyValue(c)
+	default:
+		out.Profile = in.Profile
+	}
+	out.Bank = in.Bank.DeepCopyWith(c)
+	out.Employment = in.Employment.DeepCopyWith(c)
+	if in.Friends != nil {
+		out.Friends = make([]*Person, len(in.Friends))
+		for i0 := range in.Friends {
+			out.Friends[i0] = in.Friends[i0].DeepCopyWith(c)
+		}
+	}
+	if in.Family != nil {
+		out.Family = make([]*FamilyMember, len(in.Family))
+		for i0 := range in.Family {
+			out.Family[i0] = in.Family[i0].DeepCopyWith(c)
+		}
+	}
+	switch v0 := in.Health.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Health = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Health = v0.deepCopyValue(c)
+	default:
+		out.Health = in.Health
+	}
+	switch v0 := in.Digital.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Digital = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Digital = v0.deepCopyValue(c)
+	default:
+		out.Digital = in.Digital
+	}
+	if in.TravelHistory != nil {
+		out.TravelHistory = make([]*Travel, len(in.TravelHistory))
+		for i0 := range in.TravelHistory {
+			out.TravelHistory[i0] = in.TravelHistory[i0].DeepCopyWith(c)
+		}
+	}
+	out.Preferences = in.Preferences.DeepCopyWith(c)
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Person
+func (in *Person) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Person
+func (in Person) deepCopyValue(c *builder.Copier) any {
+	var out Person
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,98 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the PersonalPreferences into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *PersonalPreferences) DeepCopyInto(out *PersonalPreferences) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the PersonalPreferences
+func (in *PersonalPreferences) DeepCopy() *PersonalPreferences {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the PersonalPreferences with c, which copies models
+// shared with other copies made by c only once
+func (in *PersonalPreferences) DeepCopyWith(c *builder.Copier) *PersonalPreferences {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(PersonalPreferences)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the PersonalPreferences into out with c
+func (in *PersonalPreferences) deepCopyInto(c *builder.Copier, out *PersonalPreferences) {
+	*out = *in
+	if in.FavoriteColors != nil {
+		out.FavoriteColors = make([]string, len(in.FavoriteColors))
+		copy(out.FavoriteColors, in.FavoriteColors)
+	}
+	if in.FavoriteFoods != nil {
+		out.FavoriteFoods = make([]string, len(in.FavoriteFoods))
+		copy(out.FavoriteFoods, in.FavoriteFoods)
+	}
+	if in.MusicTastes != nil {
+		out.MusicTastes = make([]string, len(in.MusicTastes))
+		copy(out.MusicTastes, in.MusicTastes)
+	}
+	if in.MovieGenres != nil {
+		out.MovieGenres = make([]string, len(in.MovieGenres))
+		copy(out.MovieGenres, in.MovieGenres)
+	}
+	if in.BookGenres != nil {
+		out.BookGenres = make([]string, len(in.BookGenres))
+		copy(out.BookGenres, in.BookGenres)
+	}
+	if in.Hobbies != nil {
+		out.Hobbies = make([]string, len(in.Hobbies))
+		copy(out.Hobbies, in.Hobbies)
+	}
+	if in.Interests != nil {
+		out.Interests = make([]string, len(in.Interests))
+		copy(out.Interests, in.Interests)
+	}
+	if in.Languages != nil {
+		out.Languages = make([]string, len(in.Languages))
+		copy(out.Languages, in.Languages)
+	}
+	if in.TravelPreferences != nil {
+		out.TravelPreferences = make(map[string]string, len(in.TravelPreferences))
+		for k0, v0 := range in.TravelPreferences {
+			out.TravelPreferences[k0] = v0
+		}
+	}
+	if in.ShoppingPreferences != nil {
+		out.ShoppingPreferences = make(map[string]bool, len(in.ShoppingPreferences))
+		for k0, v0 := range in.ShoppingPreferences {
+			out.ShoppingPreferences[k0] = v0
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *PersonalPreferences
+func (in *PersonalPreferences) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a PersonalPreferences
+func (in PersonalPreferences) deepCopyValue(c *builder.Copier) any {
+	var out PersonalPreferences
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,118 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Portfolio into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Portfolio) DeepCopyInto(out *Portfolio) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Portfolio
+func (in *Portfolio) DeepCopy() *Portfolio {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Portfolio with c, which copies models
+// shared with other copies made by c only once
+func (in *Portfolio) DeepCopyWith(c *builder.Copier) *Portfolio {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Portfolio)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Portfolio into out with c
+func (in *Portfolio) deepCopyInto(c *builder.Copier, out *Portfolio) {
+	*out = *in
+	if in.Allocation != nil {
+		out.Allocation = make(map[string]float64, len(in.Allocation))
+		for k0, v0 := range in.Allocation {
+			out.Allocation[k0] = v0
+		}
+	}
+	if in.Stocks != nil {
+		out.Stocks = make([]any, len(in.Stocks))
+		for i0 := range in.Stocks {
+			switch v1 := in.Stocks[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Stocks[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Stocks[i0] = v1.deepCopyValue(c)
+			default:
+				out.Stocks[i0] = in.Stocks[i0]
+			}
+		}
+	}
+	if in.Bonds != nil {
+		out.Bonds = make([]*Bond, len(in.Bonds))
+		for i0 := range in.Bonds {
+			out.Bonds[i0] = in.Bonds[i0].DeepCopyWith(c)
+		}
+	}
+	if in.ETFs != nil {
+		out.ETFs = make([]any, len(in.ETFs))
+		for i0 := range in.ETFs {
+			switch v1 := in.ETFs[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.ETFs[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.ETFs[i0] = v1.deepCopyValue(c)
+			default:
+				out.ETFs[i0] = in.ETFs[i0]
+			}
+		}
+	}
+	if in.MutualFunds != nil {
+		out.MutualFunds = make([]any, len(in.MutualFunds))
+		for i0 := range in.MutualFunds {
+			switch v1 := in.MutualFunds[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.MutualFunds[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.MutualFunds[i0] = v1.deepCopyValue(c)
+			default:
+				out.MutualFunds[i0] = in.MutualFunds[i0]
+			}
+		}
+	}
+	if in.Cryptocurrencies != nil {
+		out.Cryptocurrencies = make([]any, len(in.Cryptocurrencies))
+		for i0 := range in.Cryptocurrencies {
+			switch v1 := in.Cryptocurrencies[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Cryptocurrencies[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Cryptocurrencies[i0] = v1.deepCopyValue(c)
+			default:
+				out.Cryptocurrencies[i0] = in.Cryptocurrencies[i0]
+			}
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Portfolio
+func (in *Portfolio) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Portfolio
+func (in Portfolio) deepCopyValue(c *builder.Copier) any {
+	var out Portfolio
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,94 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Project into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Project) DeepCopyInto(out *Project) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Project
+func (in *Project) DeepCopy() *Project {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Project with c, which copies models
+// shared with other copies made by c only once
+func (in *Project) DeepCopyWith(c *builder.Copier) *Project {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Project)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Project into out with c
+func (in *Project) deepCopyInto(c *builder.Copier, out *Project) {
+	*out = *in
+	switch v0 := in.Manager.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Manager = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Manager = v0.deepCopyValue(c)
+	default:
+		out.Manager = in.Manager
+	}
+	if in.Team != nil {
+		out.Team = make([]interface{}, len(in.Team))
+		for i0 := range in.Team {
+			switch v1 := in.Team[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Team[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Team[i0] = v1.deepCopyValue(c)
+			default:
+				out.Team[i0] = in.Team[i0]
+			}
+		}
+	}
+	if in.Members != nil {
+		out.Members = make([]interface{}, len(in.Members))
+		for i0 := range in.Members {
+			switch v1 := in.Members[i0].(type) {
+			case interface{ deepCopyPointer(*builder.Copier) any }:
+				out.Members[i0] = v1.deepCopyPointer(c)
+			case interface{ deepCopyValue(*builder.Copier) any }:
+				out.Members[i0] = v1.deepCopyValue(c)
+			default:
+				out.Members[i0] = in.Members[i0]
+			}
+		}
+	}
+	if in.Tasks != nil {
+		out.Tasks = make([]*Task, len(in.Tasks))
+		for i0 := range in.Tasks {
+			out.Tasks[i0] = in.Tasks[i0].DeepCopyWith(c)
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Project
+func (in *Project) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Project
+func (in Project) deepCopyValue(c *builder.Copier) any {
+	var out Project
+	in.deepCopyInto(c, &out)
+	return out
+}
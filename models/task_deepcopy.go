@@ -0,0 +1,68 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Task into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Task) DeepCopyInto(out *Task) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Task
+func (in *Task) DeepCopy() *Task {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Task with c, which copies models
+// shared with other copies made by c only once
+func (in *Task) DeepCopyWith(c *builder.Copier) *Task {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Task)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Task into out with c
+func (in *Task) deepCopyInto(c *builder.Copier, out *Task) {
+	*out = *in
+	switch v0 := in.Assignee.(type) {
+	case interface{ deepCopyPointer(*builder.Copier) any }:
+		out.Assignee = v0.deepCopyPointer(c)
+	case interface{ deepCopyValue(*builder.Copier) any }:
+		out.Assignee = v0.deepCopyValue(c)
+	default:
+		out.Assignee = in.Assignee
+	}
+	if in.Subtasks != nil {
+		out.Subtasks = make([]*Task, len(in.Subtasks))
+		for i0 := range in.Subtasks {
+			out.Subtasks[i0] = in.Subtasks[i0].DeepCopyWith(c)
+		}
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Task
+func (in *Task) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Task
+func (in Task) deepCopyValue(c *builder.Copier) any {
+	var out Task
+	in.deepCopyInto(c, &out)
+	return out
+}
@@ -0,0 +1,59 @@
+// Code generated by builder-gen. DO NOT EDIT.
+
+package models
+
+import (
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+)
+
+// DeepCopyInto copies the Travel into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *Travel) DeepCopyInto(out *Travel) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the Travel
+func (in *Travel) DeepCopy() *Travel {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the Travel with c, which copies models
+// shared with other copies made by c only once
+func (in *Travel) DeepCopyWith(c *builder.Copier) *Travel {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new(Travel)
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the Travel into out with c
+func (in *Travel) deepCopyInto(c *builder.Copier, out *Travel) {
+	*out = *in
+	in.Destination.deepCopyInto(c, &out.Destination)
+	if in.Activities != nil {
+		out.Activities = make([]string, len(in.Activities))
+		copy(out.Activities, in.Activities)
+	}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *Travel
+func (in *Travel) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a Travel
+func (in Travel) deepCopyValue(c *builder.Copier) any {
+	var out Travel
+	in.deepCopyInto(c, &out)
+	return out
+}
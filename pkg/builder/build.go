@@ -0,0 +1,27 @@
+package builder
+
+import "fmt"
+
+// BuildAll builds and validates the objects of several builders, in order. It
+// stops at the first builder that fails validation.
+func BuildAll[T any](builders ...Builder[T]) ([]*T, error) {
+	built := make([]*T, 0, len(builders))
+	for i, b := range builders {
+		obj, err := b.BuildAndValidate()
+		if err != nil {
+			return nil, fmt.Errorf("builder %d: %w", i, err)
+		}
+		built = append(built, obj)
+	}
+	return built, nil
+}
+
+// MustBuildAll builds and validates the objects of several builders like
+// BuildAll and panics if any of them fails validation
+func MustBuildAll[T any](builders ...Builder[T]) []*T {
+	built, err := BuildAll(builders...)
+	if err != nil {
+		panic(err)
+	}
+	return built
+}
@@ -0,0 +1,46 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+)
+
+type item struct {
+	Name string
+}
+
+// itemBuilder is a minimal Builder of items
+type itemBuilder struct {
+	item item
+	err  error
+}
+
+func (b *itemBuilder) Build() item          { return b.item }
+func (b *itemBuilder) BuildPtr() *item      { built := b.item; return &built }
+func (b *itemBuilder) MustBuild() *item     { return MustBuildAll[item](b)[0] }
+func (b *itemBuilder) Clone() Builder[item] { cloned := *b; return &cloned }
+func (b *itemBuilder) BuildAndValidate() (*item, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.BuildPtr(), nil
+}
+
+func TestBuildAll(t *testing.T) {
+	items, err := BuildAll(&itemBuilder{item: item{"a"}}, &itemBuilder{item: item{"b"}})
+	if err != nil || len(items) != 2 || items[0].Name != "a" || items[1].Name != "b" {
+		t.Errorf("BuildAll() = %v, %v", items, err)
+	}
+
+	invalid := errors.New("invalid")
+	if _, err := BuildAll[item](&itemBuilder{}, &itemBuilder{err: invalid}); !errors.Is(err, invalid) || err.Error() != "builder 1: invalid" {
+		t.Errorf("Expected the error of the second builder, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MustBuildAll to panic")
+		}
+	}()
+	MustBuildAll[item](&itemBuilder{err: invalid})
+}
@@ -0,0 +1,186 @@
+package builder
+
+import (
+	"reflect"
+	"sync"
+)
+
+// DeepCopy returns a deep copy of v. Pointers, slices, maps and interfaces are
+// followed and copied, so the result shares no mutable state with v reachable
+// through exported fields. A pointer, slice or map reached twice is copied once,
+// which keeps shared objects shared and terminates on cycles.
+//
+// Unexported fields are copied as they are, since reflection cannot set them:
+// values such as time.Time keep their internal state, while unexported
+// collections remain shared with v unless a copy function is registered for
+// their struct with RegisterCopy. Functions and channels are shared as well.
+// Pointers implementing Cloner copy themselves.
+func DeepCopy[T any](v T) T {
+	return Copy(NewCopier(), v)
+}
+
+// Copy returns a deep copy of v like DeepCopy, reusing the copies c has already
+// made, so that values shared between several calls stay shared in the copies
+func Copy[T any](c *Copier, v T) T {
+	var out T
+	reflect.ValueOf(&out).Elem().Set(c.copy(reflect.ValueOf(&v).Elem()))
+	return out
+}
+
+// Cloner is implemented by pointers to values that cannot be copied through their
+// exported fields, such as generated builders. CloneWith returns a deep copy of the
+// receiver, made with c. It must Record the copy before copying anything the
+// receiver refers to, which may lead back to the receiver.
+type Cloner interface {
+	CloneWith(c *Copier) any
+}
+
+// copyFuncs holds the functions registered with RegisterCopy, keyed by struct type
+var copyFuncs sync.Map
+
+// RegisterCopy registers fn to deep-copy src into dst for values of the struct
+// type T, in place of reflection. Builders generated into the models package
+// register one for every model, so that unexported fields are copied as well.
+// fn must copy what src refers to with c, typically by Copy for every field.
+func RegisterCopy[T any](fn func(c *Copier, dst, src *T)) {
+	copyFuncs.Store(reflect.TypeOf((*T)(nil)).Elem(), func(c *Copier, dst, src reflect.Value) {
+		fn(c, dst.Interface().(*T), src.Interface().(*T))
+	})
+}
+
+// copyFunc returns the function registered with RegisterCopy for values of type t
+func copyFunc(t reflect.Type) (func(c *Copier, dst, src reflect.Value), bool) {
+	fn, ok := copyFuncs.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return fn.(func(c *Copier, dst, src reflect.Value)), true
+}
+
+// copyKey identifies a pointer, slice or map that has already been copied
+type copyKey struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// clonerType is the reflect type of Cloner
+var clonerType = reflect.TypeOf((*Cloner)(nil)).Elem()
+
+// Copier deep-copies values, remembering the copy of every pointer, slice and map
+type Copier struct {
+	copies map[copyKey]reflect.Value
+}
+
+// NewCopier creates a Copier that has not copied anything yet
+func NewCopier() *Copier {
+	return &Copier{copies: make(map[copyKey]reflect.Value)}
+}
+
+// Record records cp as the copy of v, both non-nil pointers of the same type,
+// so that v is not copied again
+func (c *Copier) Record(v, cp any) {
+	pv := reflect.ValueOf(v)
+	c.copies[copyKey{typ: pv.Type(), ptr: pv.Pointer()}] = reflect.ValueOf(cp)
+}
+
+// Copied returns the copy c has made or recorded of v, if any. Generated DeepCopy
+// methods use it to copy a model reached several times only once.
+func Copied[T any](c *Copier, v *T) (*T, bool) {
+	cp, ok := c.copies[copyKey{typ: reflect.TypeOf(v), ptr: reflect.ValueOf(v).Pointer()}]
+	if !ok {
+		return nil, false
+	}
+	return cp.Interface().(*T), true
+}
+
+// copy returns a deep copy of v
+func (c *Copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{typ: v.Type(), ptr: v.Pointer()}
+		if cp, ok := c.copies[key]; ok {
+			return cp
+		}
+		if v.Type().Implements(clonerType) && v.CanInterface() {
+			cp := reflect.ValueOf(v.Interface().(Cloner).CloneWith(c))
+			c.copies[key] = cp
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		c.copies[key] = cp
+		if fn, ok := copyFunc(v.Type().Elem()); ok && v.CanInterface() {
+			fn(c, cp, v)
+			return cp
+		}
+		cp.Elem().Set(c.copy(v.Elem()))
+		return cp
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}
+		if cp, ok := c.copies[key]; ok {
+			return cp
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		c.copies[key] = cp
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return cp
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{typ: v.Type(), ptr: v.Pointer()}
+		if cp, ok := c.copies[key]; ok {
+			return cp
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[key] = cp
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), c.copy(iter.Value()))
+		}
+		return cp
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(c.copy(v.Elem()))
+		return cp
+
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return cp
+
+	case reflect.Struct:
+		if fn, ok := copyFunc(v.Type()); ok && v.CanInterface() {
+			src := reflect.New(v.Type())
+			src.Elem().Set(v)
+			cp := reflect.New(v.Type())
+			fn(c, cp, src)
+			return cp.Elem()
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+		return cp
+	}
+	return v
+}
@@ -0,0 +1,140 @@
+package builder
+
+import (
+	"testing"
+	"time"
+)
+
+type node struct {
+	Name     string
+	Tags     []string
+	Attrs    map[string]*node
+	Next     *node
+	Children [2]*node
+	Value    interface{}
+	Created  time.Time
+	hidden   []int
+}
+
+func TestDeepCopy(t *testing.T) {
+	shared := &node{Name: "shared"}
+	src := &node{
+		Name:     "root",
+		Tags:     []string{"a", "b"},
+		Attrs:    map[string]*node{"x": shared},
+		Children: [2]*node{shared, shared},
+		Value:    &node{Name: "dynamic"},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		hidden:   []int{1},
+	}
+	src.Next = src
+
+	cp := DeepCopy(src)
+	if cp == src || cp.Name != "root" || !cp.Created.Equal(src.Created) {
+		t.Fatalf("Expected a distinct copy of root, got %+v", cp)
+	}
+
+	cp.Tags[0] = "changed"
+	if src.Tags[0] != "a" {
+		t.Errorf("Expected slices to be copied")
+	}
+	if cp.Attrs["x"] == shared || cp.Children[0] == shared {
+		t.Errorf("Expected nested pointers to be copied")
+	}
+	if cp.Attrs["x"] != cp.Children[0] || cp.Children[0] != cp.Children[1] {
+		t.Errorf("Expected a pointer reached twice to be copied once")
+	}
+	if cp.Next != cp {
+		t.Errorf("Expected the cycle to point at the copy")
+	}
+	if v, ok := cp.Value.(*node); !ok || v == src.Value || v.Name != "dynamic" {
+		t.Errorf("Expected the interface value to be copied, got %#v", cp.Value)
+	}
+	if &cp.hidden[0] != &src.hidden[0] {
+		t.Errorf("Expected unexported fields to be copied as they are")
+	}
+
+	if DeepCopy[interface{}](nil) != nil {
+		t.Errorf("Expected a nil interface to stay nil")
+	}
+	if DeepCopy([]int(nil)) != nil {
+		t.Errorf("Expected a nil slice to stay nil")
+	}
+}
+
+// cloned is copied through its unexported fields by CloneWith
+type cloned struct {
+	name  string
+	peers []*cloned
+}
+
+func (v *cloned) CloneWith(c *Copier) any {
+	cp := &cloned{name: v.name}
+	c.Record(v, cp)
+	cp.peers = Copy(c, v.peers)
+	return cp
+}
+
+func TestCopyCloner(t *testing.T) {
+	a := &cloned{name: "a"}
+	b := &cloned{name: "b", peers: []*cloned{a}}
+	a.peers = []*cloned{b, a}
+
+	c := NewCopier()
+	ca := Copy(c, a)
+	cb := Copy(c, b)
+	if ca == a || ca.name != "a" || cb == b {
+		t.Fatalf("Expected CloneWith to copy the values")
+	}
+	if ca.peers[0] != cb || ca.peers[1] != ca || cb.peers[0] != ca {
+		t.Errorf("Expected copies made with one Copier to refer to each other")
+	}
+	if DeepCopy(b).peers[0] == ca {
+		t.Errorf("Expected a new Copier to copy again")
+	}
+}
+
+// private has unexported fields copied by a registered function
+type private struct {
+	labels map[string]string
+	next   *private
+}
+
+func TestRegisterCopy(t *testing.T) {
+	RegisterCopy(func(c *Copier, dst, src *private) {
+		*dst = *src
+		dst.labels = Copy(c, src.labels)
+		dst.next = Copy(c, src.next)
+	})
+
+	src := &private{labels: map[string]string{"a": "1"}}
+	src.next = src
+	cp := DeepCopy(src)
+	cp.labels["b"] = "2"
+	if len(src.labels) != 1 {
+		t.Errorf("Expected unexported fields to be copied, got %v", src.labels)
+	}
+	if cp.next != cp {
+		t.Errorf("Expected the cycle to point at the copy")
+	}
+
+	values := []private{{labels: map[string]string{"a": "1"}}}
+	copied := DeepCopy(values)
+	copied[0].labels["b"] = "2"
+	if len(values[0].labels) != 1 {
+		t.Errorf("Expected struct values to be copied with the registered function")
+	}
+}
+
+func TestCopied(t *testing.T) {
+	c := NewCopier()
+	src := &node{Name: "root"}
+	if _, ok := Copied(c, src); ok {
+		t.Errorf("Expected nothing to be copied yet")
+	}
+	cp := &node{}
+	c.Record(src, cp)
+	if got, ok := Copied(c, src); !ok || got != cp {
+		t.Errorf("Expected the recorded copy, got %p", got)
+	}
+}
@@ -0,0 +1,45 @@
+package builder
+
+// Graph records the objects built from builders during one call of a Build method.
+// A builder reached several times through nested builders is built once, and a
+// cycle between builders becomes a cycle between the objects built from them.
+type Graph struct {
+	copier *Copier
+	built  map[any]any
+}
+
+// NewGraph creates a Graph for one build. Every builder builds a deep copy of its
+// model, made with one Copier so that models sharing values share their copies,
+// unless shared is set, in which case builders build their models themselves.
+func NewGraph(shared bool) *Graph {
+	g := &Graph{built: make(map[any]any)}
+	if !shared {
+		g.copier = NewCopier()
+	}
+	return g
+}
+
+// Enter returns the object the builder b builds within g, and whether b has been
+// entered before. The object is then the one built already, which is still being
+// built when b is reached through a cycle. Otherwise it is model, or a deep copy
+// of it, and is recorded for b.
+func Enter[T any](g *Graph, b any, model *T) (*T, bool) {
+	if obj, ok := g.built[b]; ok {
+		return obj.(*T), true
+	}
+	if g.copier != nil {
+		model = Copy(g.copier, model)
+	}
+	g.built[b] = model
+	return model, false
+}
+
+// Detach returns a pointer to a shallow copy of *p, or to a new zero value if p is
+// nil, so that fields can be set without changing the value p points to
+func Detach[T any](p *T) *T {
+	cp := new(T)
+	if p != nil {
+		*cp = *p
+	}
+	return cp
+}
@@ -0,0 +1,39 @@
+package builder
+
+import "testing"
+
+func TestGraphEnter(t *testing.T) {
+	model := &node{Name: "root", Tags: []string{"a"}}
+	b1, b2 := new(int), new(int)
+
+	g := NewGraph(false)
+	n, built := Enter(g, b1, model)
+	if built || n == model || n.Name != "root" {
+		t.Fatalf("Expected a new copy of the model, got %+v", n)
+	}
+	if again, built := Enter(g, b1, model); !built || again != n {
+		t.Errorf("Expected a builder entered twice to return its object")
+	}
+	if other, _ := Enter(g, b2, model); other != n {
+		t.Errorf("Expected copies of one model within a graph to be shared")
+	}
+	if next, _ := Enter(NewGraph(false), b1, model); next == n {
+		t.Errorf("Expected a new graph to copy again")
+	}
+
+	if shared, _ := Enter(NewGraph(true), b1, model); shared != model {
+		t.Errorf("Expected a shared graph to use the model itself")
+	}
+}
+
+func TestDetach(t *testing.T) {
+	src := &node{Name: "root"}
+	cp := Detach(src)
+	cp.Name = "changed"
+	if cp == src || src.Name != "root" {
+		t.Errorf("Expected a copy, got %+v", src)
+	}
+	if Detach[node](nil) == nil {
+		t.Errorf("Expected nil to be detached into a zero value")
+	}
+}
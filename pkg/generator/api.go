@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Input selects the Go source to generate builders for. Files are read from
+// disk, while FS and Sources are loaded in memory as if they were stored in Dir,
+// so they can import the other packages of the module containing Dir.
+type Input struct {
+	Files   []string          // Go files on disk
+	FS      fs.FS             // Go files of a file system, including subdirectories
+	Sources map[string]string // In-memory Go sources by file name, relative to Dir
+	Dir     string            // Directory FS and Sources belong to; defaults to the current directory
+}
+
+// Result is the outcome of a generation run
+type Result struct {
+	Files    []GeneratedFile // Files to write, named relative to the output directory
+	Warnings []string        // Problems that did not stop generation
+
+	gen *Generator
+}
+
+// Verify type-checks the generated files as part of the package in outputDir,
+// see Generator.Verify
+func (r *Result) Verify(outputDir string) error {
+	if r.gen == nil {
+		return nil
+	}
+	return r.gen.Verify(outputDir, r.Files)
+}
+
+// StaleFiles returns the files in outputDir that were generated for structs of
+// the input, but are no longer generated, such as those of deleted, renamed or
+// excluded structs. Only files of the configured style and templates are
+// considered, and only for packages given as a whole, not as single files.
+func (r *Result) StaleFiles(outputDir string) ([]string, error) {
+	if r.gen == nil {
+		return nil, nil
+	}
+	return r.gen.staleFiles(outputDir, r.Files)
+}
+
+// Generate generates builders for the input and returns them without writing
+// anything. The generated files are formatted; DirSink type-checks them against
+// the output package before writing.
+func (g *Generator) Generate(input Input) (*Result, error) {
+	files, err := g.prepareInput(input)
+	if err != nil {
+		return nil, err
+	}
+	g.warnings = nil
+	g.inputs = nil
+
+	var generated []GeneratedFile
+	for _, file := range files {
+		builders, err := g.GenerateFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error processing file %s: %w", file, err)
+		}
+		generated = append(generated, builders...)
+	}
+
+	// Support files come first and use the package clause worked out for the builders
+	support, err := g.SupportFiles()
+	if err != nil {
+		return nil, err
+	}
+	generated = append(support, generated...)
+
+	return &Result{Files: generated, Warnings: g.warnings, gen: g}, nil
+}
+
+// prepareInput registers in-memory sources as overlays and returns the paths of all input files
+func (g *Generator) prepareInput(input Input) ([]string, error) {
+	files := append([]string{}, input.Files...)
+	if input.FS == nil && len(input.Sources) == 0 {
+		return files, nil
+	}
+
+	dir := input.Dir
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory %s: %v", input.Dir, err)
+	}
+
+	sources := make(map[string][]byte)
+	if input.FS != nil {
+		err := fs.WalkDir(input.FS, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+				return err
+			}
+			content, err := fs.ReadFile(input.FS, path)
+			if err != nil {
+				return err
+			}
+			sources[path] = content
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sources: %v", err)
+		}
+	}
+	for name, content := range input.Sources {
+		sources[filepath.ToSlash(name)] = []byte(content)
+	}
+
+	// Loaded packages may be stale once sources change
+	g.packages = nil
+	g.overlay = make(map[string][]byte)
+
+	var names []string
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		g.overlay[path] = sources[name]
+		files = append(files, path)
+	}
+
+	return files, nil
+}
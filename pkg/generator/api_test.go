@@ -0,0 +1,317 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const virtualModelsPackage = "github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/virtual"
+
+const virtualModels = `package virtual
+
+import "time"
+
+type Event struct {
+	Name  string ` + "`builder:\"required\"`" + `
+	At    time.Time
+	Venue Venue
+}
+
+type Venue struct {
+	City string
+}
+`
+
+// resultFiles indexes the contents of generated files by name
+func resultFiles(result *Result) map[string]string {
+	files := make(map[string]string)
+	for _, file := range result.Files {
+		files[file.Name] = string(file.Content)
+	}
+	return files
+}
+
+func TestGenerateFromSources(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: virtualModelsPackage})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"models.go": virtualModels},
+		Dir:     "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := resultFiles(result)
+	for _, name := range []string{"builder_util.go", "builder_registry.go", "event_builder.go", "venue_builder.go"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Expected %s in result, got %d files", name, len(files))
+		}
+	}
+	assertContains(t, files["event_builder.go"],
+		"func (b *EventBuilder) WithAt(at time.Time) *EventBuilder",
+		"func (b *EventBuilder) WithVenue(venue *VenueBuilder) *EventBuilder",
+	)
+	if err := result.Verify(""); err != nil {
+		t.Errorf("Generated code does not compile: %v", err)
+	}
+	if _, err := os.Stat("testdata/virtual"); !os.IsNotExist(err) {
+		t.Errorf("Expected in-memory sources not to be written")
+	}
+}
+
+func TestGenerateFromFS(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: virtualModelsPackage, Style: OutputStyleOptions})
+	result, err := gen.Generate(Input{
+		FS: fstest.MapFS{
+			"models.go": {Data: []byte(virtualModels)},
+			"README.md": {Data: []byte("not Go")},
+		},
+		Dir: "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := resultFiles(result)
+	if len(files) != 2 {
+		t.Errorf("Expected only the option files, got %d files", len(files))
+	}
+	assertContains(t, files["event_options.go"], "func EventName(name string) EventOption")
+}
+
+func TestGenerateWarnings(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: testModelsPackage})
+	gen.packages = testPackages
+
+	result, err := gen.Generate(Input{Files: []string{"testdata/models/models.go"}})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "Labelled: promoted field Owner is ambiguous") {
+		t.Errorf("Expected a warning for the ambiguous Owner field, got %v", result.Warnings)
+	}
+}
+
+func TestSinks(t *testing.T) {
+	result := &Result{Files: []GeneratedFile{
+		{Name: "a_builder.go", Content: []byte("package builders\n")},
+		{Name: "b_builder.go", Content: []byte("package builders\n\nvar B = 1\n")},
+	}}
+
+	var out bytes.Buffer
+	if err := (WriterSink{W: &out}).Write(result); err != nil {
+		t.Fatalf("WriterSink failed: %v", err)
+	}
+	assertContains(t, out.String(), "// a_builder.go\npackage builders\n", "// b_builder.go\n")
+
+	var archive bytes.Buffer
+	if err := (ZipSink{W: &archive}).Write(result); err != nil {
+		t.Fatalf("ZipSink failed: %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	if err != nil {
+		t.Fatalf("Invalid archive: %v", err)
+	}
+	if len(reader.File) != 2 || reader.File[1].Name != "b_builder.go" {
+		t.Errorf("Unexpected archive contents: %v", reader.File)
+	}
+
+	dir := filepath.Join(t.TempDir(), "builders")
+	if err := (DirSink{Dir: dir}).Write(result); err != nil {
+		t.Fatalf("DirSink failed: %v", err)
+	}
+	out.Reset()
+	if err := (DiffSink{Dir: dir, W: &out}).Write(result); err != nil || out.Len() != 0 {
+		t.Errorf("Expected no drift after writing, got %v:\n%s", err, out.String())
+	}
+
+	result.Files[1].Content = []byte("package builders\n\nvar B = 2\n")
+	if err := (DiffSink{Dir: dir, W: &out}).Write(result); !errors.Is(err, ErrOutOfDate) {
+		t.Errorf("Expected ErrOutOfDate, got %v", err)
+	}
+	assertContains(t, out.String(), "-var B = 1", "+var B = 2")
+}
+
+func TestGenerateFilters(t *testing.T) {
+	input := Input{Files: []string{
+		"testdata/filtered/filtered.go",
+		"testdata/filtered/filtered_test.go",
+		"testdata/filtered/zz_generated.go",
+		"testdata/filtered/ignored.go",
+	}}
+	generate := func(opts Options) map[string]string {
+		t.Helper()
+		opts.PackageName = "builders"
+		opts.ModelsPackage = "github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/filtered"
+		opts.Style = OutputStyleOptions
+		gen := NewGenerator(opts)
+		gen.packages = testPackages
+		result, err := gen.Generate(input)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		// Types from test files are only visible to builders in the same package
+		if !opts.IncludeTests {
+			if err := result.Verify(""); err != nil {
+				t.Fatalf("Generated code does not compile: %v", err)
+			}
+		}
+		return resultFiles(result)
+	}
+	assertFiles := func(files map[string]string, expected ...string) {
+		t.Helper()
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sort.Strings(expected)
+		if strings.Join(names, " ") != strings.Join(expected, " ") {
+			t.Errorf("Expected files %v, got %v", expected, names)
+		}
+	}
+
+	files := generate(Options{Exclude: []string{"*Request"}})
+	assertFiles(files, "audit_request_options.go", "customer_options.go", "order_options.go", "span_options.go")
+	assertContains(t, files["order_options.go"], "func OrderRequest(request *filtered.OrderRequest) OrderOption")
+
+	assertFiles(generate(Options{Types: []string{"/^(Cust|Ord)/"}, Exclude: []string{"Order"}}),
+		"audit_request_options.go", "customer_options.go", "order_request_options.go")
+
+	assertFiles(generate(Options{Types: []string{"Fixture", "Generated"}, IncludeTests: true, IncludeGenerated: true}),
+		"audit_request_options.go", "fixture_options.go", "generated_options.go")
+
+	gen := NewGenerator(Options{PackageName: "builders", Exclude: []string{"/(/"}})
+	gen.packages = testPackages
+	if _, err := gen.Generate(input); err == nil {
+		t.Errorf("Expected an error for an invalid pattern")
+	}
+}
+
+func TestGenerateDetectsModelsPackage(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders"})
+	gen.packages = testPackages
+
+	result, err := gen.Generate(Input{Files: []string{"testdata/models/models.go"}})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	assertContains(t, resultFiles(result)["address_builder.go"],
+		`"`+testModelsPackage+`"`,
+		"model *models.Address",
+	)
+}
+
+func TestGenerateSamePackage(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", OutputDir: "testdata/virtual"})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"models.go": virtualModels},
+		Dir:     "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := resultFiles(result)
+	event := files["event_builder.go"]
+	assertContains(t, event,
+		"package virtual",
+		"model *Event",
+		"func (b *EventBuilder) BuildPtr() *Event",
+		"func (b *EventBuilder) WithVenue(venue *VenueBuilder) *EventBuilder",
+	)
+	if strings.Contains(event, "virtual.") || strings.Contains(event, `testdata/virtual"`) {
+		t.Errorf("Expected model types without qualifier or import:\n%s", event)
+	}
+	// The registry and utilities would become part of the models' API
+	for _, name := range []string{"builder_util.go", "builder_registry.go"} {
+		if _, ok := files[name]; ok {
+			t.Errorf("Expected no %s in the models package", name)
+		}
+	}
+
+	if err := result.Verify("testdata/virtual"); err != nil {
+		t.Errorf("Generated code does not compile with the models: %v", err)
+	}
+}
+
+func TestGenerateSamePackageTestFiles(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", SamePackage: true, TestFiles: true})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"account.go": `package virtual
+
+type Account struct {
+	Owner   string
+	balance int
+	tags    []string
+}
+`},
+		Dir: "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := resultFiles(result)
+	if _, ok := files["account_builder_test.go"]; !ok || len(files) != 1 {
+		t.Errorf("Expected only account_builder_test.go, got %v", files)
+	}
+	assertContains(t, files["account_builder_test.go"],
+		"package virtual",
+		"model *Account",
+		"func (b *AccountBuilder) WithOwner(owner string) *AccountBuilder",
+		"func (b *AccountBuilder) WithBalance(balance int) *AccountBuilder",
+		"b.model.balance = balance",
+		"func (b *AccountBuilder) AddTag(tag string) *AccountBuilder",
+		// Reflection cannot copy unexported fields, so they are copied with typed code
+		"builder.RegisterCopy(func(c *builder.Copier, dst, src *Account) {\n\t\t*dst = *src\n\t\tdst.tags = builder.Copy(c, src.tags)\n\t})",
+	)
+
+	if err := result.Verify("testdata/virtual"); err != nil {
+		t.Errorf("Generated code does not compile with the models: %v", err)
+	}
+}
+
+func TestDirSinkSwitchToTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "helper_test.go")
+	if err := os.WriteFile(helper, []byte("package builders\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var result *Result
+	for _, testFiles := range []bool{false, true} {
+		gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: testModelsPackage, TestFiles: testFiles})
+		gen.packages = testPackages
+		var err error
+		if result, err = gen.Generate(Input{Files: []string{"testdata/models/models.go"}}); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if err := (DirSink{Dir: dir}).Write(result); err != nil {
+			t.Fatalf("DirSink with test files %v failed: %v", testFiles, err)
+		}
+	}
+
+	// The files generated before are replaced by the test files
+	for _, file := range result.Files {
+		if !strings.HasSuffix(file.Name, "_test.go") {
+			t.Errorf("Expected a test file, got %s", file.Name)
+		}
+		if _, err := os.Stat(filepath.Join(dir, counterpart(file.Name))); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", counterpart(file.Name), err)
+		}
+	}
+	if _, err := os.Stat(helper); err != nil {
+		t.Errorf("Expected files written by hand to be kept: %v", err)
+	}
+	if err := result.Verify(dir); err != nil {
+		t.Errorf("Output directory does not compile: %v", err)
+	}
+}
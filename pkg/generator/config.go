@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames are the configuration files FindConfig looks for, in order
+var ConfigFileNames = []string{"builder-gen.yaml", "builder-gen.yml", "builder-gen.json"}
+
+// Settings are generator settings that can be given for a whole configuration
+// and overridden per mapping. Switches are pointers so that a mapping or flag
+// can turn off a switch that is on at the top level, and type lists are only
+// left unset when nil, so that an empty list clears the filter.
+type Settings struct {
+	PackageName      string   `yaml:"package" json:"package"`
+	ModelsPackage    string   `yaml:"models-package" json:"models-package"`
+	Style            string   `yaml:"style" json:"style"`
+	Recursive        *bool    `yaml:"recursive" json:"recursive"`
+	Types            []string `yaml:"types" json:"types"`
+	Exclude          []string `yaml:"exclude" json:"exclude"`
+	IncludeTests     *bool    `yaml:"include-tests" json:"include-tests"`
+	IncludeGenerated *bool    `yaml:"include-generated" json:"include-generated"`
+	TemplateDir      string   `yaml:"template-dir" json:"template-dir"`
+	SamePackage      *bool    `yaml:"same-package" json:"same-package"`
+	TestFiles        *bool    `yaml:"test-files" json:"test-files"`
+
+	// Required lists required fields by struct name
+	Required map[string][]string `yaml:"required" json:"required"`
+	// Fields holds builder tag directives by struct and field name
+	Fields map[string]map[string]string `yaml:"fields" json:"fields"`
+}
+
+// Mapping generates builders for the Go files in Input into Output
+type Mapping struct {
+	Input    string `yaml:"input" json:"input"`
+	Output   string `yaml:"output" json:"output"`
+	Settings `yaml:",inline"`
+}
+
+// Config is a builder-gen configuration file:
+//
+//	package: builders
+//	mappings:
+//	  - input: models
+//	    output: builders
+//	    recursive: true
+//	    style: step
+//	    exclude: ["*Request"]
+//	    required:
+//	      Person: [ID, Name]
+//	    fields:
+//	      Person:
+//	        Name: name=FullName
+//	        Secret: "-"
+//	  - input: internal/store
+//	    same-package: true
+//	    test-files: true
+//	  - input: models
+//	    same-package: true
+//	    style: deepcopy
+//
+// Settings at the top level apply to every mapping unless the mapping overrides them.
+// Mappings generating into the models package may leave out the output.
+type Config struct {
+	Settings `yaml:",inline"`
+	Mappings []Mapping `yaml:"mappings" json:"mappings"`
+}
+
+// LoadConfig reads a YAML or JSON configuration file. Relative paths in the
+// configuration are resolved against the directory of the file.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+
+	var config Config
+	if strings.HasSuffix(path, ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %v", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	config.TemplateDir = resolvePath(dir, config.TemplateDir)
+	for i := range config.Mappings {
+		m := &config.Mappings[i]
+		if m.Input == "" || (m.Output == "" && !config.Settings.Merge(m.Settings).IsSamePackage()) {
+			return nil, fmt.Errorf("invalid config %s: mapping %d needs an input and an output", path, i+1)
+		}
+		m.Input = resolvePath(dir, m.Input)
+		m.Output = resolvePath(dir, m.Output)
+		m.TemplateDir = resolvePath(dir, m.TemplateDir)
+	}
+
+	return &config, nil
+}
+
+// FindConfig returns the first of ConfigFileNames that exists in dir, or "" if there is none
+func FindConfig(dir string) string {
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// resolvePath resolves a relative path against dir, keeping empty paths empty
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// Merge returns the settings with every value set in override applied on top.
+// Required fields and field directives are merged per struct.
+func (s Settings) Merge(override Settings) Settings {
+	merged := s
+	if override.PackageName != "" {
+		merged.PackageName = override.PackageName
+	}
+	if override.ModelsPackage != "" {
+		merged.ModelsPackage = override.ModelsPackage
+	}
+	if override.Style != "" {
+		merged.Style = override.Style
+	}
+	if override.TemplateDir != "" {
+		merged.TemplateDir = override.TemplateDir
+	}
+	if override.Types != nil {
+		merged.Types = override.Types
+	}
+	if override.Exclude != nil {
+		merged.Exclude = override.Exclude
+	}
+	if override.Recursive != nil {
+		merged.Recursive = override.Recursive
+	}
+	if override.IncludeTests != nil {
+		merged.IncludeTests = override.IncludeTests
+	}
+	if override.IncludeGenerated != nil {
+		merged.IncludeGenerated = override.IncludeGenerated
+	}
+	if override.SamePackage != nil {
+		merged.SamePackage = override.SamePackage
+	}
+	if override.TestFiles != nil {
+		merged.TestFiles = override.TestFiles
+	}
+
+	if len(override.Required) > 0 {
+		merged.Required = make(map[string][]string)
+		for name, fields := range s.Required {
+			merged.Required[name] = fields
+		}
+		for name, fields := range override.Required {
+			merged.Required[name] = fields
+		}
+	}
+
+	if len(override.Fields) > 0 {
+		merged.Fields = make(map[string]map[string]string)
+		for _, fields := range []map[string]map[string]string{s.Fields, override.Fields} {
+			for name, directives := range fields {
+				if merged.Fields[name] == nil {
+					merged.Fields[name] = make(map[string]string)
+				}
+				for field, directive := range directives {
+					merged.Fields[name][field] = directive
+				}
+			}
+		}
+	}
+
+	return merged
+}
+
+// IsRecursive reports whether input directories are processed recursively
+func (s Settings) IsRecursive() bool {
+	return isOn(s.Recursive)
+}
+
+// IsSamePackage reports whether builders are generated into the models package
+func (s Settings) IsSamePackage() bool {
+	return isOn(s.SamePackage)
+}
+
+// isOn reports whether a switch is set and on
+func isOn(b *bool) bool {
+	return b != nil && *b
+}
+
+// Options returns generator options for the settings, loading the template directory if set
+func (s Settings) Options() (Options, error) {
+	opts := Options{
+		PackageName:      s.PackageName,
+		ModelsPackage:    s.ModelsPackage,
+		Style:            s.Style,
+		Types:            s.Types,
+		Exclude:          s.Exclude,
+		IncludeTests:     isOn(s.IncludeTests),
+		IncludeGenerated: isOn(s.IncludeGenerated),
+		SamePackage:      isOn(s.SamePackage),
+		TestFiles:        isOn(s.TestFiles),
+		Required:         s.Required,
+		Fields:           s.Fields,
+	}
+	if opts.PackageName == "" {
+		opts.PackageName = "builders"
+	}
+
+	if s.TemplateDir != "" {
+		templates, err := LoadTemplates(s.TemplateDir)
+		if err != nil {
+			return opts, err
+		}
+		opts.Templates = templates
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	yamlPath := writeConfig(t, "builder-gen.yaml", `
+package: builders
+style: options
+required:
+  Person: [ID]
+mappings:
+  - input: models
+    output: builders
+    recursive: true
+    style: step
+    exclude: ["*Request"]
+    fields:
+      Person:
+        Name: name=FullName
+  - input: /abs/models
+    output: gen
+`)
+	jsonPath := writeConfig(t, "builder-gen.json", `{
+  "package": "builders",
+  "style": "options",
+  "required": {"Person": ["ID"]},
+  "mappings": [
+    {"input": "models", "output": "builders", "recursive": true, "style": "step",
+     "exclude": ["*Request"], "fields": {"Person": {"Name": "name=FullName"}}},
+    {"input": "/abs/models", "output": "gen"}
+  ]
+}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		config, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) failed: %v", path, err)
+		}
+		dir := filepath.Dir(path)
+
+		if len(config.Mappings) != 2 {
+			t.Fatalf("Expected 2 mappings in %s, got %d", path, len(config.Mappings))
+		}
+		first := config.Mappings[0]
+		if first.Input != filepath.Join(dir, "models") || first.Output != filepath.Join(dir, "builders") {
+			t.Errorf("Expected paths relative to the config file, got %s -> %s", first.Input, first.Output)
+		}
+		if config.Mappings[1].Input != "/abs/models" {
+			t.Errorf("Expected absolute paths to be kept, got %s", config.Mappings[1].Input)
+		}
+
+		on := true
+		settings := config.Settings.Merge(first.Settings)
+		expected := Settings{
+			PackageName: "builders",
+			Style:       OutputStyleStep,
+			Recursive:   &on,
+			Exclude:     []string{"*Request"},
+			Required:    map[string][]string{"Person": {"ID"}},
+			Fields:      map[string]map[string]string{"Person": {"Name": "name=FullName"}},
+		}
+		if !reflect.DeepEqual(settings, expected) {
+			t.Errorf("Unexpected settings from %s:\n%+v\nexpected:\n%+v", path, settings, expected)
+		}
+
+		second := config.Settings.Merge(config.Mappings[1].Settings).Merge(Settings{Style: OutputStyleBuilder})
+		if second.Style != OutputStyleBuilder || second.IsRecursive() {
+			t.Errorf("Expected flags to override the config, got %+v", second)
+		}
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml":   "mappings:\n  - input: a\n    output: b\n    unknown: 1\n",
+		"unknown.json":   `{"mappings": [{"input": "a", "output": "b", "unknown": 1}]}`,
+		"no-input.yaml":  "mappings:\n  - output: b\n",
+		"no-output.yaml": "mappings:\n  - input: a\n",
+	} {
+		if _, err := LoadConfig(writeConfig(t, name, content)); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+
+	// Same-package mappings are written next to the models
+	config, err := LoadConfig(writeConfig(t, "same.yaml", "mappings:\n  - input: models\n    same-package: true\n    test-files: true\n"))
+	if err != nil {
+		t.Fatalf("Expected a same-package mapping without output, got %v", err)
+	}
+	if opts, _ := config.Mappings[0].Options(); !opts.SamePackage || !opts.TestFiles {
+		t.Errorf("Expected same-package test file options, got %+v", opts)
+	}
+
+	// unless they turn off same-package set at the top level
+	if _, err := LoadConfig(writeConfig(t, "off.yaml", "same-package: true\nmappings:\n  - input: models\n    same-package: false\n")); err == nil {
+		t.Error("Expected an error for a mapping turning off same-package without an output")
+	}
+}
+
+func TestMergeSwitches(t *testing.T) {
+	on, off := true, false
+	config := Settings{Recursive: &on, SamePackage: &on, TestFiles: &on}
+
+	// A mapping can turn off a switch that is on at the top level
+	merged := config.Merge(Settings{SamePackage: &off})
+	if opts, _ := merged.Options(); opts.SamePackage || !opts.TestFiles || !merged.IsRecursive() {
+		t.Errorf("Expected a mapping to turn off same-package only, got %+v", opts)
+	}
+
+	// and so can flags such as -recursive=false
+	merged = merged.Merge(Settings{Recursive: &off, TestFiles: &off})
+	if opts, _ := merged.Options(); opts.TestFiles || merged.IsRecursive() {
+		t.Errorf("Expected flags to turn off recursive and test-files, got %+v", opts)
+	}
+
+	// Switches left out keep their value
+	if merged = config.Merge(Settings{}); !merged.IsRecursive() || !merged.IsSamePackage() {
+		t.Errorf("Expected switches to be kept, got %+v", merged)
+	}
+
+	// Type filters set to an empty list, such as -types=, clear the configured ones
+	filtered := Settings{Types: []string{"Person"}, Exclude: []string{"*Request"}}
+	if merged = filtered.Merge(Settings{}); len(merged.Types) != 1 || len(merged.Exclude) != 1 {
+		t.Errorf("Expected type filters to be kept, got %+v", merged)
+	}
+	if merged = filtered.Merge(Settings{Types: []string{}, Exclude: []string{}}); len(merged.Types) != 0 || len(merged.Exclude) != 0 {
+		t.Errorf("Expected empty type filters to clear the configured ones, got %+v", merged)
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	dir := t.TempDir()
+	if path := FindConfig(dir); path != "" {
+		t.Errorf("Expected no config, got %s", path)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "builder-gen.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := FindConfig(dir); path != filepath.Join(dir, "builder-gen.json") {
+		t.Errorf("Expected builder-gen.json, got %s", path)
+	}
+}
@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// DeepCopyTemplate is the template for generating DeepCopyInto and DeepCopy methods
+// on a model, in the models package
+const DeepCopyTemplate = `package {{ .PackageName }}
+{{- if .ImportLines }}
+
+import (
+{{ .ImportLines }})
+{{- end }}
+
+// DeepCopyInto copies the {{ .Struct.Name }} into out, which must not be nil. Pointers,
+// slices and maps are copied rather than shared; interface values are copied when
+// their dynamic type is a model of this package and shared otherwise. A model
+// reached through several pointers is copied once, so cycles are kept as cycles.
+func (in *{{ $.ModelType }}) DeepCopyInto(out *{{ $.ModelType }}) {
+	c := builder.NewCopier()
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+}
+
+// DeepCopy creates a deep copy of the {{ .Struct.Name }}
+func (in *{{ $.ModelType }}) DeepCopy() *{{ $.ModelType }} {
+	return in.DeepCopyWith(builder.NewCopier())
+}
+
+// DeepCopyWith creates a deep copy of the {{ .Struct.Name }} with c, which copies models
+// shared with other copies made by c only once
+func (in *{{ $.ModelType }}) DeepCopyWith(c *builder.Copier) *{{ $.ModelType }} {
+	if in == nil {
+		return nil
+	}
+	if out, ok := builder.Copied(c, in); ok {
+		return out
+	}
+	out := new({{ $.ModelType }})
+	c.Record(in, out)
+	in.deepCopyInto(c, out)
+	return out
+}
+
+// deepCopyInto copies the {{ .Struct.Name }} into out with c
+func (in *{{ $.ModelType }}) deepCopyInto(c *builder.Copier, out *{{ $.ModelType }}) {
+	*out = *in
+	{{- range .Struct.DeepCopy }}
+	{{ . }}
+	{{- end }}
+}
+
+// deepCopyPointer returns a deep copy of an interface value holding a *{{ .Struct.Name }}
+func (in *{{ $.ModelType }}) deepCopyPointer(c *builder.Copier) any {
+	return in.DeepCopyWith(c)
+}
+
+// deepCopyValue returns a deep copy of an interface value holding a {{ .Struct.Name }}
+func (in {{ $.ModelType }}) deepCopyValue(c *builder.Copier) any {
+	var out {{ $.ModelType }}
+	in.deepCopyInto(c, &out)
+	return out
+}
+`
+
+// extractDeepCopyInfo extracts the information DeepCopyTemplate needs about a
+// struct: every field whose value *out = *in would share is copied explicitly
+func (g *Generator) extractDeepCopyInfo(resolver *typeResolver, obj *types.TypeName) (StructInfo, error) {
+	structType, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return StructInfo{}, fmt.Errorf("not a struct type: %s", obj.Name())
+	}
+
+	structInfo := StructInfo{
+		Name:    obj.Name(),
+		Imports: resolver.imports,
+	}
+	if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+		structInfo.TypeParams, structInfo.TypeArgs = resolver.typeParamLists(named.TypeParams())
+	}
+
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		if field.Name() == "_" || !resolver.needsCopy(field.Type()) {
+			continue
+		}
+		name := field.Name()
+		statements := resolver.copyStatements("out."+name, "in."+name, field.Type(), 0)
+		structInfo.DeepCopy = append(structInfo.DeepCopy, strings.Join(statements, "\n"))
+	}
+	return structInfo, nil
+}
+
+// hasDeepCopy reports whether *t has DeepCopyInto and DeepCopy methods: structs of
+// the models package that get them generated, or types declaring them already
+func (r *typeResolver) hasDeepCopy(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	if r.generatesDeepCopy(named.Obj()) {
+		return true
+	}
+	ptr := types.NewPointer(named)
+	into, _, _ := types.LookupFieldOrMethod(ptr, true, r.pkg, "DeepCopyInto")
+	deepCopy, _, _ := types.LookupFieldOrMethod(ptr, true, r.pkg, "DeepCopy")
+	if into == nil || deepCopy == nil {
+		return false
+	}
+	sig, ok := into.Type().(*types.Signature)
+	return ok && sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), ptr)
+}
+
+// copiesWith reports whether t is a struct of the models package whose generated
+// DeepCopy methods take the Copier of the enclosing copy
+func (r *typeResolver) copiesWith(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && r.generatesDeepCopy(named.Obj())
+}
+
+// needsCopy reports whether assigning a value of type t shares state that a deep
+// copy must not share. Functions, channels and structs without DeepCopy methods,
+// such as time.Time, are assigned as they are.
+func (r *typeResolver) needsCopy(t types.Type) bool {
+	if r.hasDeepCopy(t) {
+		return true
+	}
+	switch t := copyStructure(t).(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	case *types.Array:
+		return r.needsCopy(t.Elem())
+	case *types.Interface:
+		return r.copyableInterface(t)
+	}
+	return false
+}
+
+// copyableInterface reports whether a value of a struct of the models package with
+// generated DeepCopy methods, or a pointer to one, can be stored in iface
+func (r *typeResolver) copyableInterface(iface types.Type) bool {
+	scope := r.pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() || !r.generatesDeepCopy(obj) {
+			continue
+		}
+		// Generic structs cannot be checked without type arguments
+		named := obj.Type().(*types.Named)
+		if named.TypeParams().Len() > 0 {
+			continue
+		}
+		if types.AssignableTo(types.NewPointer(named), iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// generatesDeepCopy reports whether obj is a struct of the models package that
+// gets generated DeepCopy methods
+func (r *typeResolver) generatesDeepCopy(obj *types.TypeName) bool {
+	if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+		return false
+	}
+	return obj.Pkg() == r.pkg && (r.builders == nil || r.builders[obj])
+}
+
+// copyStatements returns statements making dst, an assignable expression, a deep
+// copy of src, an addressable expression of type t. Models of the package are
+// copied with the Copier c of the enclosing copy. depth keeps the names of loop
+// variables unique.
+func (r *typeResolver) copyStatements(dst, src string, t types.Type, depth int) []string {
+	if !r.needsCopy(t) {
+		return []string{dst + " = " + src}
+	}
+	if r.copiesWith(t) {
+		return []string{src + ".deepCopyInto(c, &" + dst + ")"}
+	}
+	if r.hasDeepCopy(t) {
+		return []string{src + ".DeepCopyInto(&" + dst + ")"}
+	}
+
+	typ := r.typeString(t)
+	switch u := copyStructure(t).(type) {
+	case *types.Pointer:
+		elem := r.typeString(u.Elem())
+		if r.copiesWith(u.Elem()) {
+			return []string{dst + " = " + src + ".DeepCopyWith(c)"}
+		}
+		if r.hasDeepCopy(u.Elem()) {
+			return []string{dst + " = " + src + ".DeepCopy()"}
+		}
+		lines := []string{
+			"if " + src + " != nil {",
+			"\t" + dst + " = new(" + elem + ")",
+		}
+		lines = append(lines, indent(r.copyStatements("(*"+dst+")", "(*"+src+")", u.Elem(), depth))...)
+		return append(lines, "}")
+
+	case *types.Slice:
+		lines := []string{
+			"if " + src + " != nil {",
+			fmt.Sprintf("\t%s = make(%s, len(%s))", dst, typ, src),
+		}
+		if !r.needsCopy(u.Elem()) {
+			lines = append(lines, fmt.Sprintf("\tcopy(%s, %s)", dst, src))
+		} else {
+			i := fmt.Sprintf("i%d", depth)
+			lines = append(lines, fmt.Sprintf("\tfor %s := range %s {", i, src))
+			lines = append(lines, indent(indent(r.copyStatements(dst+"["+i+"]", src+"["+i+"]", u.Elem(), depth+1)))...)
+			lines = append(lines, "\t}")
+		}
+		return append(lines, "}")
+
+	case *types.Array:
+		i := fmt.Sprintf("i%d", depth)
+		lines := []string{fmt.Sprintf("for %s := range %s {", i, src)}
+		lines = append(lines, indent(r.copyStatements(dst+"["+i+"]", src+"["+i+"]", u.Elem(), depth+1))...)
+		return append(lines, "}")
+
+	case *types.Map:
+		k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+		lines := []string{
+			"if " + src + " != nil {",
+			fmt.Sprintf("\t%s = make(%s, len(%s))", dst, typ, src),
+		}
+		if !r.needsCopy(u.Elem()) {
+			lines = append(lines,
+				fmt.Sprintf("\tfor %s, %s := range %s {", k, v, src),
+				fmt.Sprintf("\t\t%s[%s] = %s", dst, k, v))
+		} else {
+			// Map elements are not addressable, so they are copied through a variable
+			// unless the copy is a single expression
+			c := fmt.Sprintf("c%d", depth)
+			statements := r.copyStatements(c, v, u.Elem(), depth+1)
+			lines = append(lines, fmt.Sprintf("\tfor %s, %s := range %s {", k, v, src))
+			if expr, ok := strings.CutPrefix(statements[0], c+" = "); ok && len(statements) == 1 {
+				lines = append(lines, fmt.Sprintf("\t\t%s[%s] = %s", dst, k, expr))
+			} else {
+				lines = append(lines, fmt.Sprintf("\t\tvar %s %s", c, r.typeString(u.Elem())))
+				lines = append(lines, indent(indent(statements))...)
+				lines = append(lines, fmt.Sprintf("\t\t%s[%s] = %s", dst, k, c))
+			}
+		}
+		return append(lines, "\t}", "}")
+
+	case *types.Interface:
+		// Models with generated DeepCopy methods copy themselves; a pointer has
+		// both methods, a value only deepCopyValue. Other values are shared.
+		v := fmt.Sprintf("v%d", depth)
+		assert := ""
+		if u.NumMethods() > 0 {
+			assert = ".(" + typ + ")"
+		}
+		return []string{
+			fmt.Sprintf("switch %s := %s.(type) {", v, src),
+			"case interface{ deepCopyPointer(*builder.Copier) any }:",
+			fmt.Sprintf("\t%s = %s.deepCopyPointer(c)%s", dst, v, assert),
+			"case interface{ deepCopyValue(*builder.Copier) any }:",
+			fmt.Sprintf("\t%s = %s.deepCopyValue(c)%s", dst, v, assert),
+			"default:",
+			fmt.Sprintf("\t%s = %s", dst, src),
+			"}",
+		}
+	}
+	return []string{dst + " = " + src}
+}
+
+// copyStructure returns the type that decides how a value of type t is copied:
+// its collection type like structure, or the interface underlying a named interface
+func copyStructure(t types.Type) types.Type {
+	t = structure(t)
+	if _, ok := t.(*types.TypeParam); ok {
+		return t
+	}
+	if iface, ok := t.Underlying().(*types.Interface); ok {
+		return iface
+	}
+	return t
+}
+
+// copyFields returns the fields of a struct that an assignment would share with
+// the original, if any of them is unexported, and nil otherwise
+func copyFields(st *types.Struct) []string {
+	var fields []string
+	unexported := false
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Name() == "_" || !sharesState(field.Type()) {
+			continue
+		}
+		fields = append(fields, field.Name())
+		unexported = unexported || !field.Exported()
+	}
+	if !unexported {
+		return nil
+	}
+	return fields
+}
+
+// sharesState reports whether assigning a value of type t shares state with the
+// original that builder.Copy would copy. Functions and channels are shared by
+// Copy as well.
+func sharesState(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Basic, *types.Signature, *types.Chan:
+		return false
+	case *types.Array:
+		return sharesState(u.Elem())
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if sharesState(u.Field(i).Type()) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// indent indents generated statements by one level
+func indent(lines []string) []string {
+	indented := make([]string, len(lines))
+	for i, line := range lines {
+		indented[i] = "\t" + line
+	}
+	return indented
+}
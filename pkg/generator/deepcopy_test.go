@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+const deepCopyModels = `package virtual
+
+import "time"
+
+type Shape interface {
+	Area() float64
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Node[T any] struct {
+	Value T
+	Next  *Node[T]
+}
+
+type Drawing struct {
+	Name     string
+	Created  time.Time
+	Owner    *Square
+	Squares  []Square
+	Layers   map[string][]*Square
+	Grid     [2][]int
+	Main     Shape
+	Extra    any
+	Notes    []string
+	List     Node[int]
+	callback func()
+	hidden   map[string]int
+}
+`
+
+func TestGenerateDeepCopy(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", SamePackage: true, Style: OutputStyleDeepCopy})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"drawing.go": deepCopyModels},
+		Dir:     "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := resultFiles(result)
+	if len(files) != 3 {
+		t.Errorf("Expected one file per struct and no support files, got %v", files)
+	}
+	drawing := files["drawing_deepcopy.go"]
+	assertContains(t, drawing,
+		"func (in *Drawing) deepCopyInto(c *builder.Copier, out *Drawing) {\n\t*out = *in",
+		"func (in *Drawing) DeepCopyInto(out *Drawing) {\n\tc := builder.NewCopier()\n\tc.Record(in, out)",
+		"out.Owner = in.Owner.DeepCopyWith(c)",
+		"in.Squares[i0].deepCopyInto(c, &out.Squares[i0])",
+		"out.Layers = make(map[string][]*Square, len(in.Layers))",
+		"var c0 []*Square",
+		"c0[i1] = v0[i1].DeepCopyWith(c)",
+		"for i0 := range in.Grid {",
+		"copy(out.Grid[i0], in.Grid[i0])",
+		"case interface{ deepCopyPointer(*builder.Copier) any }:\n\t\tout.Main = v0.deepCopyPointer(c).(Shape)",
+		"case interface{ deepCopyValue(*builder.Copier) any }:\n\t\tout.Extra = v0.deepCopyValue(c)\n\tdefault:\n\t\tout.Extra = in.Extra",
+		"copy(out.Notes, in.Notes)",
+		"in.List.deepCopyInto(c, &out.List)",
+		"out.hidden = make(map[string]int, len(in.hidden))",
+		"func (in *Drawing) DeepCopy() *Drawing {",
+		// Models reached twice are copied once, which keeps cycles and shared pointers
+		"if out, ok := builder.Copied(c, in); ok {\n\t\treturn out\n\t}",
+		"func (in Drawing) deepCopyValue(c *builder.Copier) any {",
+	)
+	for _, shared := range []string{"Created", "callback", "out.Name"} {
+		if strings.Contains(drawing, shared) {
+			t.Errorf("Expected %s to be copied by *out = *in only", shared)
+		}
+	}
+	assertContains(t, files["node_deepcopy.go"],
+		"func (in *Node[T]) DeepCopyInto(out *Node[T]) {",
+		"out.Next = in.Next.DeepCopyWith(c)",
+	)
+	if strings.Contains(files["node_deepcopy.go"], "in.Value") {
+		t.Errorf("Expected values of type parameters to be assigned")
+	}
+
+	if err := result.Verify("testdata/virtual"); err != nil {
+		t.Errorf("Generated code does not compile with the models: %v", err)
+	}
+
+	// Methods cannot be declared on the models from another package
+	gen = NewGenerator(Options{PackageName: "builders", ModelsPackage: virtualModelsPackage, Style: OutputStyleDeepCopy})
+	_, err = gen.Generate(Input{
+		Sources: map[string]string{"drawing.go": deepCopyModels},
+		Dir:     "testdata/virtual",
+	})
+	if err == nil || !strings.Contains(err.Error(), "must be generated into their package") {
+		t.Errorf("Expected an error outside the models package, got %v", err)
+	}
+}
+
+func TestGenerateDeepCopyAfterFieldRemoved(t *testing.T) {
+	opts := Options{PackageName: "builders", SamePackage: true, Style: OutputStyleDeepCopy}
+	person := `package virtual
+
+type Person struct {
+	Name string
+	Tags []string
+}
+`
+	result, err := NewGenerator(opts).Generate(Input{
+		Sources: map[string]string{"person.go": person},
+		Dir:     "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	stale := resultFiles(result)["person_deepcopy.go"]
+	assertContains(t, stale, "in.Tags")
+
+	// The previously generated file no longer compiles against the model, which
+	// must not keep it from being regenerated
+	gen := NewGenerator(opts)
+	result, err = gen.Generate(Input{
+		Sources: map[string]string{
+			"person.go":          strings.Replace(person, "\tTags []string\n", "", 1),
+			"person_deepcopy.go": stale,
+		},
+		Dir: "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate after removing a field failed: %v", err)
+	}
+	if code := resultFiles(result)["person_deepcopy.go"]; strings.Contains(code, "Tags") {
+		t.Errorf("Expected the removed field to be gone:\n%s", code)
+	}
+	if err := result.Verify("testdata/virtual"); err != nil {
+		t.Errorf("Regenerated code does not compile with the models: %v", err)
+	}
+}
+
+func TestGenerateWithModelsUsingDeepCopy(t *testing.T) {
+	person := `package virtual
+
+type Person struct {
+	Name string
+	Tags []string
+}
+`
+	snapshot := `package virtual
+
+// Snapshot returns a copy of the person that later changes do not affect
+func (p *Person) Snapshot() *Person { return p.DeepCopy() }
+`
+	deepCopyOpts := Options{PackageName: "builders", SamePackage: true, Style: OutputStyleDeepCopy}
+	result, err := NewGenerator(deepCopyOpts).Generate(Input{
+		Sources: map[string]string{"person.go": person},
+		Dir:     "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	sources := map[string]string{
+		"person.go":          person,
+		"snapshot.go":        snapshot,
+		"person_deepcopy.go": resultFiles(result)["person_deepcopy.go"],
+	}
+
+	// Generated methods that hand-written code of the models calls stay loaded,
+	// both when generating builders and when regenerating the methods themselves
+	for _, style := range []string{OutputStyleBuilder, OutputStyleDeepCopy} {
+		opts := deepCopyOpts
+		opts.Style = style
+		if _, err := NewGenerator(opts).Generate(Input{Sources: sources, Dir: "testdata/virtual"}); err != nil {
+			t.Errorf("Generate(%s) with models calling DeepCopy failed: %v", style, err)
+		}
+	}
+}
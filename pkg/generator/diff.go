@@ -0,0 +1,300 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// CheckFiles compares generated files against the files in outputDir and
+// returns a unified diff for every file that is missing or out of date, followed
+// by a diff removing each of the stale files, see Result.StaleFiles.
+func CheckFiles(outputDir string, files []GeneratedFile, stale ...string) ([]string, error) {
+	var diffs []string
+	for _, file := range files {
+		path := filepath.Join(outputDir, file.Name)
+		oldName := path
+
+		current, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			oldName = os.DevNull
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+
+		if bytes.Equal(current, file.Content) {
+			continue
+		}
+		diffs = append(diffs, UnifiedDiff(oldName, path, string(current), string(file.Content)))
+	}
+
+	for _, path := range stale {
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		diffs = append(diffs, UnifiedDiff(path, os.DevNull, string(current), ""))
+	}
+	return diffs, nil
+}
+
+// staleFiles returns the files in outputDir that builder-gen generated for the
+// structs of the input packages with the configured style and templates, but no
+// longer generates. A file belongs to an input package when it is named after
+// one of its structs, or refers to the package because the struct was deleted.
+// Packages only given in part, such as a single file, are left out, since the
+// other structs of the package are not generated.
+func (g *Generator) staleFiles(outputDir string, files []GeneratedFile) ([]string, error) {
+	var pkgs []*packages.Package
+	structs := make(map[string]bool)
+	for pkg, inputs := range g.inputs {
+		if !coversPackage(inputs, pkg) {
+			continue
+		}
+		pkgs = append(pkgs, pkg)
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			if _, ok := obj.Type().Underlying().(*types.Struct); ok {
+				structs[ToSnakeCase(name)] = true
+			}
+		}
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(outputDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", outputDir, err)
+	}
+	absDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, file := range files {
+		names[file.Name] = true
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		name := entry.Name()
+		base, ok := g.structFileBase(name)
+		if entry.IsDir() || names[name] || !ok {
+			continue
+		}
+		path := filepath.Join(outputDir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		if !bytes.HasPrefix(content, []byte(generatedHeader)) {
+			continue
+		}
+		if structs[base] || refersTo(absDir, path, content, pkgs) {
+			stale = append(stale, path)
+		}
+	}
+	return stale, nil
+}
+
+// structFileBase returns the snake case struct name of a file named like those
+// generated per struct with the configured style and templates, as a test file
+// or not, and whether the name matched
+func (g *Generator) structFileBase(name string) (string, bool) {
+	name = strings.Replace(name, "_test.go", ".go", 1)
+	for _, suffix := range append([]string{g.fileSuffix()}, g.Options.Templates.extraSuffixes()...) {
+		if base, ok := strings.CutSuffix(name, suffix); ok && base != "" {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// coversPackage reports whether inputs, a set of absolute paths, holds every Go
+// file of pkg
+func coversPackage(inputs map[string]bool, pkg *packages.Package) bool {
+	for _, file := range pkg.GoFiles {
+		if !inputs[file] {
+			return false
+		}
+	}
+	return true
+}
+
+// refersTo reports whether the generated file at path, in dir, belongs to one of
+// pkgs: it is in the directory of the package or imports it
+func refersTo(dir, path string, content []byte, pkgs []*packages.Package) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), path, content, parser.ImportsOnly)
+	if err != nil {
+		return false
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.GoFiles) > 0 && filepath.Dir(pkg.GoFiles[0]) == dir {
+			return true
+		}
+		for _, spec := range f.Imports {
+			if importPath, err := strconv.Unquote(spec.Path.Value); err == nil && importPath == pkg.PkgPath {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// diffOp is a single line of a diff: ' ' kept, '-' removed or '+' added
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns the unified diff between two texts, or "" if they are equal
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+
+	// Group changes into hunks, merging changes separated by little context
+	for start := 0; start < len(ops); {
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		first := max(start-diffContext, 0)
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		last := min(end+diffContext, len(ops))
+
+		writeHunk(&out, ops, first, last)
+		start = last
+	}
+
+	return out.String()
+}
+
+// writeHunk writes ops[first:last] as a single hunk with its line ranges
+func writeHunk(out *strings.Builder, ops []diffOp, first, last int) {
+	oldLine, newLine := 1, 1
+	for _, op := range ops[:first] {
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+
+	var oldCount, newCount int
+	for _, op := range ops[first:last] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	// An empty range starts at the line before it
+	if oldCount == 0 {
+		oldLine--
+	}
+	if newCount == 0 {
+		newLine--
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+	for _, op := range ops[first:last] {
+		out.WriteByte(op.kind)
+		out.WriteString(op.line)
+		out.WriteByte('\n')
+	}
+}
+
+// splitLines splits text into lines without their terminators
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes a line diff from the longest common subsequence of a and b
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
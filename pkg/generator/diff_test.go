@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	if diff := UnifiedDiff("a", "b", "same\n", "same\n"); diff != "" {
+		t.Errorf("Expected no diff for equal texts, got:\n%s", diff)
+	}
+
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	newText := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n15\n16\n"
+	expected := `--- old.go
++++ new.go
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -11,5 +11,5 @@
+ 11
+ 12
+ 13
+-14
+ 15
++16
+`
+	if diff := UnifiedDiff("old.go", "new.go", oldText, newText); diff != expected {
+		t.Errorf("Unexpected diff:\n%s\nexpected:\n%s", diff, expected)
+	}
+
+	expected = "--- /dev/null\n+++ new.go\n@@ -0,0 +1,2 @@\n+a\n+b\n"
+	if diff := UnifiedDiff("/dev/null", "new.go", "", "a\nb\n"); diff != expected {
+		t.Errorf("Unexpected diff for a new file:\n%s", diff)
+	}
+}
+
+func TestCheckFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "current.go"), []byte("package builders\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stale.go"), []byte("package builders\n\nvar x = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diffs, err := CheckFiles(dir, []GeneratedFile{
+		{Name: "current.go", Content: []byte("package builders\n")},
+		{Name: "stale.go", Content: []byte("package builders\n\nvar x = 2\n")},
+		{Name: "missing.go", Content: []byte("package builders\n")},
+	})
+	if err != nil {
+		t.Fatalf("CheckFiles failed: %v", err)
+	}
+	if len(diffs) != 2 {
+		t.Fatalf("Expected 2 diffs, got %d:\n%s", len(diffs), strings.Join(diffs, "\n"))
+	}
+	assertContains(t, diffs[0], "-var x = 1", "+var x = 2")
+	assertContains(t, diffs[1], "--- "+os.DevNull, "+package builders")
+
+	// Stale files get a diff removing them
+	removed := filepath.Join(dir, "removed_builder.go")
+	if err := os.WriteFile(removed, []byte(generatedHeader+"package builders\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	diffs, err = CheckFiles(dir, []GeneratedFile{{Name: "current.go", Content: []byte("package builders\n")}}, removed)
+	if err != nil {
+		t.Fatalf("CheckFiles failed: %v", err)
+	}
+	if len(diffs) != 1 {
+		t.Fatalf("Expected 1 diff, got %d:\n%s", len(diffs), strings.Join(diffs, "\n"))
+	}
+	assertContains(t, diffs[0], "--- "+removed, "+++ "+os.DevNull, "-package builders")
+}
+
+func TestStaleFiles(t *testing.T) {
+	const filteredPackage = "github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/filtered"
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		// excluded by name
+		"order_request_builder.go": generatedHeader + "package builders\n",
+		// of a deleted struct
+		"removed_builder_test.go": generatedHeader + "package builders\n\nimport _ \"" + filteredPackage + "\"\n",
+		// of another models package
+		"person_builder.go": generatedHeader + "package builders\n\nimport _ \"example.com/models\"\n",
+		// of another style
+		"order_request_deepcopy.go": generatedHeader + "package builders\n",
+		// written by hand
+		"customer_request_builder.go": "package builders\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: filteredPackage, Exclude: []string{"*Request"}})
+	result, err := gen.Generate(Input{Files: []string{
+		"testdata/filtered/filtered.go",
+		"testdata/filtered/zz_generated.go",
+	}})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	stale, err := result.StaleFiles(dir)
+	if err != nil {
+		t.Fatalf("StaleFiles failed: %v", err)
+	}
+	expected := []string{filepath.Join(dir, "order_request_builder.go"), filepath.Join(dir, "removed_builder_test.go")}
+	if strings.Join(stale, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected stale files %v, got %v", expected, stale)
+	}
+
+	// A single file of the package says nothing about the other structs
+	result, err = gen.Generate(Input{Files: []string{"testdata/filtered/filtered.go"}})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if stale, err := result.StaleFiles(dir); err != nil || len(stale) != 0 {
+		t.Errorf("Expected no stale files for a single input file, got %v, %v", stale, err)
+	}
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// deprecatedPrefix starts the paragraph of a comment that marks a deprecated identifier
+const deprecatedPrefix = "Deprecated: "
+
+// fieldDocs finds the doc and line comments of struct fields in the syntax of
+// a loaded package and its dependencies, which are indexed on first use
+type fieldDocs struct {
+	root    *packages.Package
+	indexed map[string]bool
+	fields  map[token.Pos]*ast.Field
+}
+
+// newFieldDocs creates a fieldDocs for the fields of pkg and the packages it imports
+func newFieldDocs(pkg *packages.Package) *fieldDocs {
+	return &fieldDocs{
+		root:    pkg,
+		indexed: make(map[string]bool),
+		fields:  make(map[token.Pos]*ast.Field),
+	}
+}
+
+// comment returns the documentation of a struct field, split into the text and
+// the deprecation notice
+func (d *fieldDocs) comment(v *types.Var) (doc, deprecated string) {
+	if d == nil || v.Pkg() == nil {
+		return "", ""
+	}
+	if !d.indexed[v.Pkg().Path()] {
+		d.index(v.Pkg().Path())
+	}
+
+	field := d.fields[v.Pos()]
+	if field == nil {
+		return "", ""
+	}
+	var paragraphs []string
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if text := strings.TrimSpace(group.Text()); text != "" {
+			paragraphs = append(paragraphs, strings.Split(text, "\n\n")...)
+		}
+	}
+
+	var docs []string
+	for _, p := range paragraphs {
+		if strings.HasPrefix(p, deprecatedPrefix) {
+			deprecated = p
+			continue
+		}
+		docs = append(docs, p)
+	}
+	return strings.Join(docs, "\n\n"), deprecated
+}
+
+// index records the fields declared in the syntax of the package with the given path
+func (d *fieldDocs) index(path string) {
+	d.indexed[path] = true
+	packages.Visit([]*packages.Package{d.root}, func(pkg *packages.Package) bool {
+		if pkg.PkgPath != path {
+			return true
+		}
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				st, ok := n.(*ast.StructType)
+				if !ok {
+					return true
+				}
+				for _, field := range st.Fields.List {
+					for _, name := range field.Names {
+						d.fields[name.Pos()] = field
+					}
+					if len(field.Names) == 0 {
+						d.fields[embeddedIdent(field.Type).Pos()] = field
+					}
+				}
+				return true
+			})
+		}
+		return false
+	}, nil)
+}
+
+// embeddedIdent returns the identifier naming an embedded field, whose position
+// go/types records for the field, e.g. Base for *common.Base
+func embeddedIdent(expr ast.Expr) ast.Node {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return e.Sel
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		default:
+			return expr
+		}
+	}
+}
+
+// commentLines renders text as comment lines, keeping blank lines between paragraphs
+func commentLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			lines = append(lines, "//")
+			continue
+		}
+		lines = append(lines, "// "+line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DocComment returns the field's documentation, including its deprecation
+// notice, as comment lines to append to the godoc of its setter
+func (f StructField) DocComment() string {
+	var comment string
+	if f.Doc != "" {
+		comment = "\n//\n" + commentLines(f.Doc)
+	}
+	return comment + f.DeprecatedComment()
+}
+
+// DeprecatedComment returns the field's deprecation notice as comment lines to
+// append to the godoc of every setter of a deprecated field
+func (f StructField) DeprecatedComment() string {
+	if f.Deprecated == "" {
+		return ""
+	}
+	return "\n//\n" + f.DeprecationNotice()
+}
+
+// DeprecationNotice returns the field's deprecation notice as comment lines,
+// for interface methods that have no other documentation
+func (f StructField) DeprecationNotice() string {
+	if f.Deprecated == "" {
+		return ""
+	}
+	return commentLines(f.Deprecated)
+}
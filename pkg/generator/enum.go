@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// EnumValue is a constant declared for the named type of a field, such as
+// StatusActive for a field of type Status
+type EnumValue struct {
+	Name  string // Constant name without the type name prefix, e.g. Active for With<Field>Active
+	Value string // Constant as written in generated code, e.g. models.StatusActive
+	Const string // Name of the declared constant
+
+	// key identifies the constant value, so that constants with the same value
+	// appear only once in validation
+	key    string
+	isZero bool
+}
+
+// enumValues returns the constants of the enum named basic type t, in declaration
+// order. Only types declared in the models package are enums, and their values are
+// the constants of t in the first const block declaring one: constants of types
+// such as time.Duration, or declared elsewhere, are not treated as a closed set.
+// A block needs at least two distinct values of t, so that a single bound or
+// sentinel such as `const MaxPercent Percent = 100` does not close the type.
+func (r *typeResolver) enumValues(t types.Type) []EnumValue {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() != r.pkg || named.TypeArgs().Len() > 0 {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return nil
+	}
+
+	scope := named.Obj().Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && types.Identical(c.Type(), named) && r.accessible(c) && r.constBlocks[c.Pos()] != nil {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return nil
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+	block := r.constBlocks[consts[0].Pos()]
+	consts = slices.DeleteFunc(consts, func(c *types.Const) bool { return r.constBlocks[c.Pos()] != block })
+
+	distinct := make(map[string]bool)
+	for _, c := range consts {
+		distinct[c.Val().ExactString()] = true
+	}
+	if len(distinct) < 2 {
+		return nil
+	}
+
+	var values []EnumValue
+	for _, c := range consts {
+		// StatusActive becomes Active for a Status, other names are kept whole
+		name := strings.TrimPrefix(c.Name(), named.Obj().Name())
+		if name == "" || name == c.Name() || ToUpperFirst(name) != name {
+			name = ToUpperFirst(c.Name())
+		}
+
+		value := c.Name()
+		if qualifier := r.qualifier(c.Pkg()); qualifier != "" {
+			value = qualifier + "." + value
+		}
+		values = append(values, EnumValue{
+			Name:   name,
+			Value:  value,
+			Const:  c.Name(),
+			key:    c.Val().ExactString(),
+			isZero: isZeroConstant(c.Val()),
+		})
+	}
+	return values
+}
+
+// constBlocks maps the position of every constant declared in the syntax of pkg
+// to the const declaration containing it
+func constBlocks(pkg *packages.Package) map[token.Pos]*ast.GenDecl {
+	blocks := make(map[token.Pos]*ast.GenDecl)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					blocks[name.Pos()] = gen
+				}
+			}
+		}
+	}
+	return blocks
+}
+
+// isZeroConstant reports whether v is the zero value of its kind
+func isZeroConstant(v constant.Value) bool {
+	switch v.Kind() {
+	case constant.String:
+		return constant.StringVal(v) == ""
+	case constant.Bool:
+		return !constant.BoolVal(v)
+	case constant.Int, constant.Float, constant.Complex:
+		return constant.Sign(v) == 0
+	}
+	return false
+}
+
+// EnumCases returns the values validation accepts for an enum field: each
+// declared constant once and, unless the field is required, the zero value
+// of an unset field
+func (f StructField) EnumCases() []string {
+	var cases []string
+	seen := make(map[string]bool)
+	allowZero := !f.Required
+	for _, v := range f.Enum {
+		if v.isZero {
+			allowZero = false
+		}
+		if !seen[v.key] {
+			seen[v.key] = true
+			cases = append(cases, v.Value)
+		}
+	}
+	if allowZero && len(cases) > 0 {
+		cases = append(cases, f.ZeroValue)
+	}
+	return cases
+}
+
+// HasEnums reports whether any field is validated against its declared constants
+func (s StructInfo) HasEnums() bool {
+	for _, field := range s.Fields {
+		if len(field.Enum) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Comment directives on type declarations, which take precedence over Options.Types and Options.Exclude
+const (
+	// DirectiveGenerate generates a builder for the type even if the filters exclude it
+	DirectiveGenerate = "//builder:generate"
+	// DirectiveSkip never generates a builder for the type
+	DirectiveSkip = "//builder:skip"
+)
+
+// typePattern matches type names against a glob, or a regular expression when
+// written between slashes (/Request$/)
+type typePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// parseTypePatterns compiles type name patterns
+func parseTypePatterns(patterns []string) ([]typePattern, error) {
+	var parsed []typePattern
+	for _, p := range patterns {
+		if len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile(p[1 : len(p)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid type pattern %s: %v", p, err)
+			}
+			parsed = append(parsed, typePattern{re: re})
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid type pattern %s: %v", p, err)
+		}
+		parsed = append(parsed, typePattern{glob: p})
+	}
+	return parsed, nil
+}
+
+// matchTypePatterns reports whether name matches any of the patterns
+func matchTypePatterns(patterns []typePattern, name string) bool {
+	for _, p := range patterns {
+		if p.re != nil && p.re.MatchString(name) {
+			return true
+		}
+		if p.re == nil {
+			if ok, _ := path.Match(p.glob, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeDirective returns the builder directive in the given doc comments, if any
+func typeDirective(docs ...*ast.CommentGroup) string {
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		for _, c := range doc.List {
+			if text := strings.TrimSpace(c.Text); text == DirectiveGenerate || text == DirectiveSkip {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// includeType reports whether a struct gets a builder, from its directive and the type filters
+func (g *Generator) includeType(name, directive string) (bool, error) {
+	switch directive {
+	case DirectiveGenerate:
+		return true, nil
+	case DirectiveSkip:
+		return false, nil
+	}
+
+	if len(g.Options.Types) > 0 {
+		types, err := parseTypePatterns(g.Options.Types)
+		if err != nil {
+			return false, err
+		}
+		if !matchTypePatterns(types, name) {
+			return false, nil
+		}
+	}
+
+	exclude, err := parseTypePatterns(g.Options.Exclude)
+	if err != nil {
+		return false, err
+	}
+	return !matchTypePatterns(exclude, name), nil
+}
+
+// includeFile reports whether structs in a file get builders. Test files and
+// generated files are skipped unless the options include them.
+func (g *Generator) includeFile(filename string, file *ast.File) bool {
+	if strings.HasSuffix(filename, "_test.go") && !g.Options.IncludeTests {
+		return false
+	}
+	if ast.IsGenerated(file) && !g.Options.IncludeGenerated {
+		return false
+	}
+	return true
+}
+
+// structDecl is a struct type declaration with the builder directive from its doc comments
+type structDecl struct {
+	Obj       *types.TypeName
+	Directive string
+}
+
+// fileStructs returns the struct type declarations of a file
+func fileStructs(pkg *packages.Package, file *ast.File) []structDecl {
+	var structs []structDecl
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			if _, ok := typeSpec.Type.(*ast.StructType); !ok {
+				continue
+			}
+			obj, ok := pkg.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+			if !ok {
+				continue
+			}
+
+			// A single declaration without parentheses carries its doc comment on the GenDecl
+			docs := []*ast.CommentGroup{typeSpec.Doc}
+			if !genDecl.Lparen.IsValid() {
+				docs = append(docs, genDecl.Doc)
+			}
+			structs = append(structs, structDecl{Obj: obj, Directive: typeDirective(docs...)})
+		}
+	}
+	return structs
+}
+
+// buildableTypes returns the structs of a package that get builders, so that
+// fields of excluded structs get plain setters instead of nested builders.
+// Unexported structs only get builders when generating into the package.
+func (g *Generator) buildableTypes(pkg *packages.Package, samePackage bool) (map[*types.TypeName]bool, error) {
+	buildable := make(map[*types.TypeName]bool)
+	for i, file := range pkg.Syntax {
+		if i < len(pkg.CompiledGoFiles) && !g.includeFile(pkg.CompiledGoFiles[i], file) {
+			continue
+		}
+		for _, decl := range fileStructs(pkg, file) {
+			if !decl.Obj.Exported() && !samePackage {
+				continue
+			}
+			include, err := g.includeType(decl.Obj.Name(), decl.Directive)
+			if err != nil {
+				return nil, err
+			}
+			if include {
+				buildable[decl.Obj] = true
+			}
+		}
+	}
+	return buildable, nil
+}
@@ -2,27 +2,92 @@ package generator
 
 import (
 	"fmt"
-	"go/ast"
-	"go/parser"
-	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"text/template"
 	"unicode"
+
+	"golang.org/x/tools/go/packages"
 )
 
 // Options contains options for the generator
 type Options struct {
 	PackageName   string
-	ModelsPackage string
+	ModelsPackage string // Import path of the models; detected from go.mod or go.work when empty
+	Style         string // OutputStyleBuilder (default), OutputStyleOptions, OutputStyleStep or OutputStyleDeepCopy
 	Verbose       bool
+
+	// OutputDir is the directory generated files are written to. When it is the
+	// models package itself, model types are not qualified or imported and the
+	// files use the models' package clause.
+	OutputDir string
+	// SamePackage generates builders for the models package itself regardless of
+	// OutputDir: model types are not qualified and unexported fields get setters.
+	// The files must be written into the models' directory.
+	SamePackage bool
+	// TestFiles names generated files *_test.go, so that builders are only
+	// compiled into tests. Together with SamePackage, models can use their own
+	// builders in tests without an import cycle.
+	TestFiles bool
+
+	// Required lists required fields by struct name, in addition to fields
+	// tagged builder:"required"
+	Required map[string][]string
+	// Fields holds builder tag directives by struct and field name, such as
+	// "name=FullName" or "-", applied on top of the fields' struct tags
+	Fields map[string]map[string]string
+
+	// Types limits generation to structs matching these patterns; Exclude skips
+	// structs matching them. Patterns are globs, or regular expressions between
+	// slashes (/Request$/). Type directives take precedence.
+	Types   []string
+	Exclude []string
+	// IncludeTests and IncludeGenerated generate builders for structs in
+	// _test.go files and generated files, which are skipped by default
+	IncludeTests     bool
+	IncludeGenerated bool
+
+	// Templates overrides the built-in templates or adds extra per-struct ones
+	Templates Templates
+	// Funcs are extra functions available to templates, see RegisterFunc
+	Funcs template.FuncMap
 }
 
+// Output styles for generated code
+const (
+	// OutputStyleBuilder generates a fluent <T>Builder per struct
+	OutputStyleBuilder = "builder"
+	// OutputStyleOptions generates a <T>Option type, one option per field and a New<T> constructor
+	OutputStyleOptions = "options"
+	// OutputStyleStep generates staged builders that require the required fields to be set,
+	// in order, before the optional setters and Build methods become available
+	OutputStyleStep = "step"
+	// OutputStyleDeepCopy generates DeepCopyInto and DeepCopy methods on the models
+	// themselves, so it must be generated into the models package
+	OutputStyleDeepCopy = "deepcopy"
+)
+
+// BuilderPackage is the import path of the runtime package generated builders use
+const BuilderPackage = "github.com/adil-faiyaz98/go-builder-kit/pkg/builder"
+
 // Generator generates builder code for structs
 type Generator struct {
 	Options Options
+
+	// packages caches loaded packages by directory, and packages loaded for
+	// imports of generated code by import path
+	packages map[string][]*packages.Package
+	// overlay holds in-memory sources by absolute path
+	overlay map[string][]byte
+	// warnings collects problems that did not stop generation
+	warnings []string
+	// samePackage is the name of the models package when output is generated into it
+	samePackage string
+	// inputs holds the absolute paths of the input files of each loaded package
+	inputs map[*packages.Package]map[string]bool
 }
 
 // NewGenerator creates a new Generator
@@ -35,87 +100,358 @@ func NewGenerator(opts Options) *Generator {
 // StructField represents a field in a struct
 type StructField struct {
 	Name         string
-	Type         string
+	Type         string   // Qualified type as written in generated code
+	Kind         TypeKind // Kind of the type at the core of the field
+	Ref          *TypeRef // Recursive description of the field type
 	IsPointer    bool
 	IsSlice      bool
+	IsArray      bool
 	IsMap        bool
 	IsNested     bool
 	IsBuiltin    bool
-	ElementType  string // For slices
-	KeyType      string // For maps
-	ValType      string // For maps
-	ImportNeeded string // Import path needed for this field
-	BuilderName  string // Name of the builder for nested structs
+	ElementType  string      // For slices
+	KeyType      string      // For maps
+	ValType      string      // For maps
+	ImportNeeded string      // Import path needed for this field
+	BuilderName  string      // Name of the builder for nested structs
+	ZeroValue    string      // Initial value used by the builder constructor
+	MethodName   string      // Name used in generated method names, e.g. With<MethodName>
+	ParamName    string      // Parameter name of setters, safe from keywords and shadowing
+	ItemName     string      // Parameter name of Add helpers for slices
+	Required     bool        // Whether BuildAndValidate rejects a zero value
+	ZeroCheck    string      // Expression reporting whether a required field is unset
+	Default      string      // Go expression assigned by New<T>BuilderWithDefaults
+	Path         string      // Selector from the model to the field, e.g. BaseEntity.ID for promoted fields
+	IsPromoted   bool        // Whether the field is promoted from an embedded struct
+	IsOverride   bool        // Whether the field is promoted through an embedded field with a nested builder
+	Enum         []EnumValue // Declared constants of the field's named type, which validation accepts
+	Doc          string      // Doc and line comments of the field, without the deprecation notice
+	Deprecated   string      // Deprecation notice of the field, starting with "Deprecated: "
+	EmbedInits   []EmbedInit
+
+	typ types.Type // Type of the field, for the zero check of required fields
+}
+
+// EmbedInit is an embedded pointer that must be allocated before a promoted field can be set
+type EmbedInit struct {
+	Path string // Selector from the model to the embedded pointer
+	Type string // Type the pointer points to
 }
 
 // StructInfo represents information about a struct
 type StructInfo struct {
-	Name   string
-	Fields []StructField
+	Name       string
+	Fields     []StructField
+	Imports    map[string]bool // Import paths needed by field types
+	TypeParams string          // Type parameter list with constraints for generic structs, e.g. [T any]
+	TypeArgs   string          // Type parameter names for generic structs, e.g. [T]
+	VarName    string          // Name of the model variable in generated code, safe from keywords and shadowing
+
+	// ModelsPackage is the import path of the package declaring the struct and
+	// ModelsName the qualifier for its types; both are empty when the builder is
+	// generated into that package
+	ModelsPackage string
+	ModelsName    string
+
+	// DeepCopy holds the statements of DeepCopyInto that copy the fields *out = *in
+	// would share, for the deepcopy style
+	DeepCopy []string
+
+	// CopyFields names the fields builders generated into the models package copy
+	// with a copy function registered by builder.RegisterCopy, since reflection
+	// cannot copy unexported ones. Empty when reflection copies the struct.
+	CopyFields []string
+}
+
+// RequiredStep is a stage of a step builder that sets one required field
+type RequiredStep struct {
+	Field     StructField
+	Interface string // Name of the step interface, including type arguments
+	Next      string // Interface returned once the field is set
+}
+
+// Steps returns the stages of a step builder, one per required field in declaration order
+func (s StructInfo) Steps() []RequiredStep {
+	var steps []RequiredStep
+	for _, field := range s.Fields {
+		if !field.Required {
+			continue
+		}
+		step := RequiredStep{Field: field, Interface: s.Name + field.MethodName + "Step" + s.TypeArgs}
+		if n := len(steps); n > 0 {
+			steps[n-1].Next = step.Interface
+		}
+		steps = append(steps, step)
+	}
+	if n := len(steps); n > 0 {
+		steps[n-1].Next = s.Name + "OptionalStep" + s.TypeArgs
+	}
+	return steps
+}
+
+// HasRequired reports whether any field is marked required by a struct tag
+func (s StructInfo) HasRequired() bool {
+	for _, field := range s.Fields {
+		if field.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// Overrides returns the promoted fields without builders of their own that are
+// set through embed, an embedded field with a nested builder. Values set with
+// their setters override those of the struct the nested builder builds.
+func (s StructInfo) Overrides(embed StructField) []StructField {
+	var fields []StructField
+	for _, field := range s.Fields {
+		if field.IsOverride && !field.Ref.HasBuilder() && strings.HasPrefix(field.Path, embed.Path+".") {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// HasOverrides reports whether any promoted field overrides a struct built by a
+// nested builder for an embedded field
+func (s StructInfo) HasOverrides() bool {
+	for _, field := range s.Fields {
+		if field.IsOverride && !field.Ref.HasBuilder() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDefaults reports whether any field has a default value from a struct tag
+func (s StructInfo) HasDefaults() bool {
+	for _, field := range s.Fields {
+		if field.Default != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratedFile is a generated source file, named relative to the output directory
+type GeneratedFile struct {
+	Name    string
+	Content []byte
 }
 
 // ProcessFile processes a single Go file and generates builders for all structs
 func (g *Generator) ProcessFile(inputFile, outputDir string) error {
+	files, err := g.GenerateFile(inputFile)
+	if err != nil {
+		return err
+	}
+	return g.WriteFiles(outputDir, files)
+}
+
+// GenerateFile generates builders for all structs in a single Go file without writing them
+func (g *Generator) GenerateFile(inputFile string) ([]GeneratedFile, error) {
 	if g.Options.Verbose {
 		fmt.Printf("Processing file: %s\n", inputFile)
 	}
+	if strings.HasSuffix(inputFile, "_test.go") && !g.Options.IncludeTests {
+		if g.Options.Verbose {
+			fmt.Printf("Skipping test file: %s\n", inputFile)
+		}
+		return nil, nil
+	}
 
-	// Parse the Go file
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, inputFile, nil, parser.ParseComments)
+	// Load the package containing the file with full type information
+	loaded, err := g.loadFile(inputFile)
 	if err != nil {
-		return fmt.Errorf("failed to parse file %s: %v", inputFile, err)
+		return nil, err
+	}
+	if loaded == nil {
+		if g.Options.Verbose {
+			fmt.Printf("Skipping file excluded by build constraints: %s\n", inputFile)
+		}
+		return nil, nil
+	}
+	g.recordInput(loaded.Package, inputFile)
+	node := loaded.File
+	if !g.includeFile(inputFile, node) {
+		if g.Options.Verbose {
+			fmt.Printf("Skipping generated file: %s\n", inputFile)
+		}
+		return nil, nil
 	}
 
-	// Extract package name if not provided
-	if g.Options.ModelsPackage == "" {
-		g.Options.ModelsPackage = node.Name.Name
+	// Work out how generated code refers to the models
+	modelsPackage, modelsName, err := g.modelsPackage(loaded.Package)
+	if err != nil {
+		return nil, err
 	}
 
-	// Find all structs in the file
-	var structs []*ast.TypeSpec
-	for _, decl := range node.Decls {
-		if genDecl, ok := decl.(*ast.GenDecl); ok {
-			for _, spec := range genDecl.Specs {
-				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-					if _, ok := typeSpec.Type.(*ast.StructType); ok {
-						structs = append(structs, typeSpec)
-					}
-				}
-			}
-		}
+	// Methods can only be declared in the models package
+	if g.Options.Style == OutputStyleDeepCopy && modelsName != "" {
+		return nil, fmt.Errorf("the %s style declares methods on the models and must be generated into their package %s (see -same-package)", OutputStyleDeepCopy, modelsPackage)
+	}
+
+	// Only structs selected by the filters and directives get builders
+	buildable, err := g.buildableTypes(loaded.Package, modelsName == "")
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate builders for each struct
-	for _, structType := range structs {
+	var files []GeneratedFile
+	docs := newFieldDocs(loaded.Package)
+	blocks := constBlocks(loaded.Package)
+	for _, decl := range fileStructs(loaded.Package, node) {
+		structType := decl.Obj
+		if !buildable[structType] {
+			if g.Options.Verbose {
+				fmt.Printf("Skipping struct: %s\n", structType.Name())
+			}
+			continue
+		}
 		if g.Options.Verbose {
-			fmt.Printf("Generating builder for struct: %s\n", structType.Name.Name)
+			fmt.Printf("Generating builder for struct: %s\n", structType.Name())
 		}
 
 		// Extract struct information
-		structInfo, err := g.extractStructInfo(structType)
+		resolver := newTypeResolver(loaded.Package.Types, modelsName)
+		resolver.builders = buildable
+		resolver.docs = docs
+		resolver.constBlocks = blocks
+		extract := g.extractStructInfo
+		if g.Options.Style == OutputStyleDeepCopy {
+			extract = g.extractDeepCopyInfo
+		}
+		structInfo, err := extract(resolver, structType)
 		if err != nil {
-			return fmt.Errorf("failed to extract struct info for %s: %v", structType.Name.Name, err)
+			return nil, fmt.Errorf("failed to extract struct info for %s: %v", structType.Name(), err)
 		}
+		structInfo.ModelsPackage = modelsPackage
+		structInfo.ModelsName = modelsName
 
 		// Generate builder code
 		builderCode, err := g.generateBuilderCode(structInfo)
 		if err != nil {
-			return fmt.Errorf("failed to generate builder code for %s: %v", structType.Name.Name, err)
+			return nil, fmt.Errorf("failed to generate builder code for %s: %v", structType.Name(), err)
 		}
 
-		// Write builder to file
-		outputFile := filepath.Join(outputDir, ToSnakeCase(structType.Name.Name)+"_builder.go")
-		if err := os.WriteFile(outputFile, []byte(builderCode), 0644); err != nil {
-			return fmt.Errorf("failed to write builder file %s: %v", outputFile, err)
+		name := g.outputName(ToSnakeCase(structType.Name()) + g.fileSuffix())
+		content, err := formatSource(name, []byte(builderCode))
+		if err != nil {
+			return nil, err
 		}
+		files = append(files, GeneratedFile{Name: name, Content: content})
 
-		if g.Options.Verbose {
-			fmt.Printf("Generated builder file: %s\n", outputFile)
+		// Generate files from extra templates
+		extra, err := g.generateExtraCode(structInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate extra code for %s: %v", structType.Name(), err)
 		}
+		files = append(files, extra...)
 	}
 
-	return nil
+	return files, nil
+}
+
+// modelsPackage returns the import path of the models package and the qualifier
+// for its types. The import path is taken from Options.ModelsPackage or, when
+// that is empty, from the module the package belongs to. Both are empty when
+// the output directory is the models package itself.
+func (g *Generator) modelsPackage(pkg *packages.Package) (string, string, error) {
+	same, err := g.isOutputPackage(pkg)
+	if err != nil {
+		return "", "", err
+	}
+	if same {
+		g.samePackage = pkg.Types.Name()
+		return "", "", nil
+	}
+
+	path := g.Options.ModelsPackage
+	if path == "" {
+		path = pkg.PkgPath
+	}
+	return path, pkg.Types.Name(), nil
+}
+
+// isOutputPackage reports whether builders are generated into pkg, either because
+// Options.SamePackage is set or because Options.OutputDir is the directory of pkg
+func (g *Generator) isOutputPackage(pkg *packages.Package) (bool, error) {
+	if g.Options.SamePackage {
+		return true, nil
+	}
+	if g.Options.OutputDir == "" || len(pkg.GoFiles) == 0 {
+		return false, nil
+	}
+
+	outputDir, err := filepath.Abs(g.Options.OutputDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve output directory %s: %v", g.Options.OutputDir, err)
+	}
+	if outputDir == filepath.Dir(pkg.GoFiles[0]) {
+		return true, nil
+	}
+
+	// Directories outside a module cannot be the models package
+	path, err := ImportPath(outputDir)
+	if err != nil {
+		return false, nil
+	}
+	return path == pkg.PkgPath, nil
+}
+
+// packageName returns the package clause of generated files, which is the
+// models package when generating into it
+func (g *Generator) packageName() string {
+	if g.samePackage != "" {
+		return g.samePackage
+	}
+	return g.Options.PackageName
+}
+
+// SupportFiles returns the utility and registry files shared by generated builders.
+// Functional options, step builders and deep copy methods need neither, and
+// builders generated into the models package leave them out so that they do not
+// add a registry and utilities to the API of the models.
+func (g *Generator) SupportFiles() ([]GeneratedFile, error) {
+	switch g.Options.Style {
+	case OutputStyleOptions, OutputStyleStep, OutputStyleDeepCopy:
+		return nil, nil
+	}
+	if g.samePackage != "" {
+		return nil, nil
+	}
+
+	util, registry := UtilTemplate, RegistryTemplate
+	if g.Options.Templates.Util != "" {
+		util = g.Options.Templates.Util
+	}
+	if g.Options.Templates.Registry != "" {
+		registry = g.Options.Templates.Registry
+	}
+
+	data := struct {
+		PackageName   string
+		ModelsPackage string
+	}{g.packageName(), g.Options.ModelsPackage}
+
+	var files []GeneratedFile
+	for _, support := range []struct{ name, file, text string }{
+		{"util", "builder_util.go", util},
+		{"registry", "builder_registry.go", registry},
+	} {
+		code, err := g.render(support.name, support.text, data)
+		if err != nil {
+			return nil, err
+		}
+		name := g.outputName(support.file)
+		content, err := formatSource(name, []byte(fmt.Sprintf("package %s\n\n%s", g.packageName(), code)))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, GeneratedFile{Name: name, Content: content})
+	}
+	return files, nil
 }
 
 // ProcessDirectory processes all Go files in a directory and generates builders for all structs
@@ -151,150 +487,478 @@ func (g *Generator) ProcessDirectory(inputDir, outputDir string, recursive bool)
 		}
 	}
 
-	// Process each Go file
+	// Generate builders for every file before writing any of them
+	var generated []GeneratedFile
 	for _, file := range goFiles {
-		if err := g.ProcessFile(file, outputDir); err != nil {
+		files, err := g.GenerateFile(file)
+		if err != nil {
 			return err
 		}
+		generated = append(generated, files...)
 	}
 
-	return nil
+	return g.WriteFiles(outputDir, generated)
 }
 
 // extractStructInfo extracts information about a struct
-func (g *Generator) extractStructInfo(typeSpec *ast.TypeSpec) (StructInfo, error) {
-	structType, ok := typeSpec.Type.(*ast.StructType)
+func (g *Generator) extractStructInfo(resolver *typeResolver, obj *types.TypeName) (StructInfo, error) {
+	structType, ok := obj.Type().Underlying().(*types.Struct)
 	if !ok {
-		return StructInfo{}, fmt.Errorf("not a struct type: %s", typeSpec.Name.Name)
+		return StructInfo{}, fmt.Errorf("not a struct type: %s", obj.Name())
 	}
 
 	structInfo := StructInfo{
-		Name:   typeSpec.Name.Name,
-		Fields: []StructField{},
+		Name:    obj.Name(),
+		Fields:  []StructField{},
+		Imports: resolver.imports,
+	}
+
+	// Carry type parameters of generic structs through to the builder
+	if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+		structInfo.TypeParams, structInfo.TypeArgs = resolver.typeParamLists(named.TypeParams())
 	}
 
 	// Extract fields
-	for _, field := range structType.Fields.List {
-		if len(field.Names) == 0 {
-			// Skip embedded fields
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+
+		tag, err := g.fieldTag(obj.Name(), field.Name(), structType.Tag(i))
+		if err != nil {
+			return StructInfo{}, fmt.Errorf("invalid builder tag on %s.%s: %v", obj.Name(), field.Name(), err)
+		}
+		if tag.Skip {
 			continue
 		}
 
-		fieldName := field.Names[0].Name
-		fieldType, isPointer, isSlice, isMap, isNested, isBuiltin, elementType, keyType, valType, importNeeded, builderName := g.extractFieldType(field.Type)
-
-		structInfo.Fields = append(structInfo.Fields, StructField{
-			Name:         fieldName,
-			Type:         fieldType,
-			IsPointer:    isPointer,
-			IsSlice:      isSlice,
-			IsMap:        isMap,
-			IsNested:     isNested,
-			IsBuiltin:    isBuiltin,
-			ElementType:  elementType,
-			KeyType:      keyType,
-			ValType:      valType,
-			ImportNeeded: importNeeded,
-			BuilderName:  builderName,
-		})
+		// Unexported fields can only be set from the models package; promoted
+		// fields of an unexported embedded struct may still be accessible
+		if g.settable(resolver, obj, field) {
+			fieldInfo := g.extractFieldType(resolver, field.Name(), field.Type())
+			fieldInfo.Doc, fieldInfo.Deprecated = resolver.docs.comment(field)
+			if err := applyFieldTag(resolver, &fieldInfo, tag); err != nil {
+				return StructInfo{}, fmt.Errorf("invalid builder tag on %s.%s: %v", obj.Name(), field.Name(), err)
+			}
+			structInfo.Fields = append(structInfo.Fields, fieldInfo)
+		}
+
+		// Follow embedded structs and add setters for their promoted fields
+		if field.Embedded() {
+			promoted, err := g.extractPromotedFields(resolver, obj, i)
+			if err != nil {
+				return StructInfo{}, err
+			}
+			structInfo.Fields = append(structInfo.Fields, promoted...)
+		}
+	}
+
+	// Reflection cannot copy unexported fields when the model is built or cloned,
+	// so builders in the models package copy them with typed code
+	if resolver.modelsName == "" && structInfo.TypeParams == "" {
+		structInfo.CopyFields = copyFields(structType)
+	}
+
+	// Promoted fields of embedded structs with nested builders are applied over
+	// the structs the nested builders build
+	embeds := make(map[string]bool)
+	for _, field := range structInfo.Fields {
+		if field.Ref.HasBuilder() {
+			embeds[field.Path] = true
+		}
+	}
+	for i := range structInfo.Fields {
+		field := &structInfo.Fields[i]
+		parts := strings.Split(field.Path, ".")
+		for j := 1; j < len(parts) && field.IsPromoted; j++ {
+			if embeds[strings.Join(parts[:j], ".")] {
+				field.IsOverride = true
+			}
+		}
 	}
 
+	g.resolveNames(resolver, &structInfo)
 	return structInfo, nil
 }
 
-// extractFieldType extracts type information from a field
-func (g *Generator) extractFieldType(expr ast.Expr) (string, bool, bool, bool, bool, bool, string, string, string, string, string) {
-	// fieldType, isPointer, isSlice, isMap, isNested, isBuiltin, elementType, keyType, valType, importNeeded, builderName
-	switch t := expr.(type) {
-	case *ast.Ident:
-		// Basic type (e.g., string, int)
-		isBuiltin := isBuiltinType(t.Name)
-		isNested := !isBuiltin && t.Name != "any" && t.Name != "interface{}"
-		builderName := ""
-		if isNested {
-			builderName = t.Name + "Builder"
-		}
-		return t.Name, false, false, false, isNested, isBuiltin, "", "", "", "", builderName
-
-	case *ast.StarExpr:
-		// Pointer type (e.g., *Person)
-		fieldType, _, _, _, isNested, isBuiltin, _, _, _, importNeeded, builderName := g.extractFieldType(t.X)
-		return fieldType, true, false, false, isNested, isBuiltin, "", "", "", importNeeded, builderName
-
-	case *ast.ArrayType:
-		// Slice type (e.g., []string, []*Person)
-		elementType, isPointer, _, _, isNested, isBuiltin, _, _, _, importNeeded, builderName := g.extractFieldType(t.Elt)
-		sliceType := ""
-		if isPointer {
-			sliceType = fmt.Sprintf("[]*%s", elementType)
-		} else {
-			sliceType = fmt.Sprintf("[]%s", elementType)
-		}
-		return sliceType, isPointer, true, false, isNested, isBuiltin, elementType, "", "", importNeeded, builderName
-
-	case *ast.MapType:
-		// Map type (e.g., map[string]int)
-		keyType, _, _, _, _, _, _, _, _, _, _ := g.extractFieldType(t.Key)
-		valType, _, _, _, isNested, _, _, _, _, importNeeded, builderName := g.extractFieldType(t.Value)
-		return fmt.Sprintf("map[%s]%s", keyType, valType), false, false, true, isNested, false, "", keyType, valType, importNeeded, builderName
-
-	case *ast.SelectorExpr:
-		// Qualified type (e.g., time.Time)
-		if ident, ok := t.X.(*ast.Ident); ok {
-			builderName := ""
-			// Only consider it a builder if it's from our models package
-			if ident.Name == "models" || ident.Name == g.Options.ModelsPackage || strings.HasSuffix(ident.Name, "/models") {
-				builderName = t.Sel.Name + "Builder"
+// settable reports whether the builder can set field of obj, which requires
+// generated code to refer to both the field and its type
+func (g *Generator) settable(resolver *typeResolver, obj *types.TypeName, field *types.Var) bool {
+	if !resolver.accessible(field) {
+		if g.Options.Verbose {
+			fmt.Printf("Skipping unexported field: %s.%s\n", obj.Name(), field.Name())
+		}
+		return false
+	}
+	if !resolver.accessibleType(field.Type()) {
+		g.warnf("%s: field %s has an unexported type of another package, no setter generated", obj.Name(), field.Name())
+		return false
+	}
+	return true
+}
+
+// extractPromotedFields extracts the fields promoted into a struct through the
+// embedded field at embedIndex. Go's promotion rules are applied by looking each
+// candidate name up on the outer struct: fields shadowed by a shallower field and
+// ambiguous fields at the same depth are not promoted and get no setter.
+func (g *Generator) extractPromotedFields(resolver *typeResolver, obj *types.TypeName, embedIndex int) ([]StructField, error) {
+	embedded := obj.Type().Underlying().(*types.Struct).Field(embedIndex)
+
+	var fields []StructField
+	seen := make(map[string]bool)
+	for _, name := range promotedFieldNames(embedded.Type(), make(map[*types.Named]bool)) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		found, index, _ := types.LookupFieldOrMethod(obj.Type(), true, resolver.pkg, name)
+		if found == nil && index != nil {
+			g.warnf("%s: promoted field %s is ambiguous, no setter generated", obj.Name(), name)
+			continue
+		}
+		field, ok := found.(*types.Var)
+		if !ok || !field.IsField() || len(index) < 2 || index[0] != embedIndex {
+			continue
+		}
+		if !g.settable(resolver, obj, field) {
+			continue
+		}
+
+		// Walk the embedding path, collecting embedded pointers that must be allocated
+		var path []string
+		var inits []EmbedInit
+		var tag string
+		accessible := true
+		current := obj.Type()
+		for i, idx := range index {
+			st := derefStruct(current)
+			f := st.Field(idx)
+			if i == len(index)-1 {
+				path = append(path, f.Name())
+				tag = st.Tag(idx)
+				break
+			}
+			current = f.Type()
+
+			// Fields promoted through an unexported embedded value are selected
+			// by their promoted name instead
+			_, isPtr := types.Unalias(f.Type()).(*types.Pointer)
+			if !resolver.accessible(f) && !isPtr {
+				path = nil
+				continue
+			}
+			path = append(path, f.Name())
+
+			if ptr, ok := types.Unalias(f.Type()).(*types.Pointer); ok {
+				if !isAccessible(resolver, f, ptr.Elem()) {
+					accessible = false
+					break
+				}
+				embedPath := strings.Join(path, ".")
+				inits = append(inits, EmbedInit{Path: embedPath, Type: resolver.typeString(ptr.Elem())})
 			}
-			return t.Sel.Name, false, false, false, true, false, "", "", "", ident.Name, builderName
 		}
-		return "interface{}", false, false, false, false, false, "", "", "", "", ""
+		if !accessible {
+			g.warnf("%s: promoted field %s is behind an embedded pointer that cannot be allocated, no setter generated", obj.Name(), name)
+			continue
+		}
 
-	case *ast.InterfaceType:
-		// Interface type
-		return "interface{}", false, false, false, false, true, "", "", "", "", ""
+		fieldTag, err := g.fieldTag(obj.Name(), name, tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid builder tag on %s.%s: %v", obj.Name(), strings.Join(path, "."), err)
+		}
+		if fieldTag.Skip {
+			continue
+		}
 
-	default:
-		// Unknown type
-		return "interface{}", false, false, false, false, true, "", "", "", "", ""
+		fieldInfo := g.extractFieldType(resolver, name, field.Type())
+		fieldInfo.Doc, fieldInfo.Deprecated = resolver.docs.comment(field)
+		fieldInfo.Path = strings.Join(path, ".")
+		fieldInfo.IsPromoted = true
+		fieldInfo.EmbedInits = inits
+		if err := applyFieldTag(resolver, &fieldInfo, fieldTag); err != nil {
+			return nil, fmt.Errorf("invalid builder tag on %s.%s: %v", obj.Name(), fieldInfo.Path, err)
+		}
+		fields = append(fields, fieldInfo)
+	}
+
+	return fields, nil
+}
+
+// promotedFieldNames lists the names of all fields reachable through an embedded
+// type, depth first in declaration order
+func promotedFieldNames(t types.Type, seen map[*types.Named]bool) []string {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if seen[named] {
+			return nil
+		}
+		seen[named] = true
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < st.NumFields(); i++ {
+		names = append(names, st.Field(i).Name())
+		if st.Field(i).Embedded() {
+			names = append(names, promotedFieldNames(st.Field(i).Type(), seen)...)
+		}
+	}
+	return names
+}
+
+// derefStruct returns the struct underlying t or *t
+func derefStruct(t types.Type) *types.Struct {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, _ := t.Underlying().(*types.Struct)
+	return st
+}
+
+// isAccessible reports whether generated code can allocate the embedded pointer
+// field f of type *elem
+func isAccessible(resolver *typeResolver, f *types.Var, elem types.Type) bool {
+	return resolver.accessible(f) && resolver.accessibleType(elem)
+}
+
+// warnf records a warning once, printing it in verbose mode
+func (g *Generator) warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	for _, warning := range g.warnings {
+		if warning == msg {
+			return
+		}
+	}
+	g.warnings = append(g.warnings, msg)
+	if g.Options.Verbose {
+		fmt.Printf("Warning: %s\n", msg)
+	}
+}
+
+// fieldTag parses the builder struct tag of a field and applies the directives
+// from Options.Fields and Options.Required on top of it
+func (g *Generator) fieldTag(structName, fieldName, rawTag string) (FieldTag, error) {
+	tag, err := ParseFieldTag(rawTag)
+	if err != nil {
+		return tag, err
+	}
+
+	if options, ok := g.Options.Fields[structName][fieldName]; ok {
+		override, err := ParseFieldOptions(options)
+		if err != nil {
+			return tag, fmt.Errorf("in configuration: %v", err)
+		}
+		tag = tag.merge(override)
+	}
+
+	for _, name := range g.Options.Required[structName] {
+		if name == fieldName {
+			tag.Required = true
+		}
+	}
+	return tag, nil
+}
+
+// applyFieldTag applies the directives of a builder struct tag to a field
+func applyFieldTag(resolver *typeResolver, field *StructField, tag FieldTag) error {
+	if tag.Name != "" {
+		field.MethodName = tag.Name
 	}
+
+	switch tag.Style {
+	case StyleValue:
+		field.IsNested = false
+		field.BuilderName = ""
+		field.Ref = field.Ref.withoutBuilders()
+	case StyleBuilder:
+		if field.Kind != KindStruct {
+			return fmt.Errorf("style=%s requires a struct from the models package, got %s", StyleBuilder, field.Type)
+		}
+	}
+
+	if tag.NoEnum {
+		field.Enum = nil
+	}
+
+	if tag.Required {
+		field.Required = true
+	}
+
+	if tag.HasDefault {
+		field.Default = resolver.defaultValue(field.typ, tag.Default)
+	}
+
+	return nil
+}
+
+// extractFieldType extracts type information from a field
+func (g *Generator) extractFieldType(resolver *typeResolver, name string, t types.Type) StructField {
+	field := StructField{
+		Name:       name,
+		Path:       name,
+		MethodName: ToUpperFirst(name),
+		Type:       resolver.typeString(t),
+		Ref:        resolver.newTypeRef(t),
+		ZeroValue:  resolver.zeroValue(t),
+		typ:        t,
+	}
+
+	// Find the type at the core of the field, e.g. Person for []*Person or for
+	// a named type People []*Person
+	core := t
+	switch t := structure(t).(type) {
+	case *types.Pointer:
+		field.IsPointer = true
+		core = t.Elem()
+
+	case *types.Slice:
+		field.IsSlice = true
+		core = t.Elem()
+		if ptr, ok := types.Unalias(core).(*types.Pointer); ok {
+			field.IsPointer = true
+			core = ptr.Elem()
+		}
+		field.ElementType = resolver.typeString(core)
+
+	case *types.Array:
+		field.IsArray = true
+		core = t.Elem()
+		field.ElementType = resolver.typeString(core)
+
+	case *types.Map:
+		field.IsMap = true
+		field.KeyType = resolver.typeString(t.Key())
+		field.ValType = resolver.typeString(t.Elem())
+		core = t.Elem()
+	}
+
+	field.Kind = resolver.classify(core)
+	field.IsNested = field.Kind == KindStruct
+	field.IsBuiltin = field.Kind == KindBasic
+	if field.Ref.Shape == ShapeValue && field.Kind == KindNamedBasic {
+		field.Enum = resolver.enumValues(t)
+	}
+	if field.IsNested {
+		field.BuilderName = resolver.builderName(core)
+	}
+	if named, ok := types.Unalias(core).(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg() != resolver.pkg {
+		field.ImportNeeded = named.Obj().Pkg().Path()
+	}
+
+	return field
 }
 
-// generateBuilderCode generates builder code for a struct
+// fileSuffix returns the suffix of generated files for the configured style
+func (g *Generator) fileSuffix() string {
+	switch g.Options.Style {
+	case OutputStyleOptions:
+		return "_options.go"
+	case OutputStyleDeepCopy:
+		return "_deepcopy.go"
+	}
+	return "_builder.go"
+}
+
+// outputName returns the name of a generated file, turned into a test file when
+// Options.TestFiles is set
+func (g *Generator) outputName(name string) string {
+	if g.Options.TestFiles {
+		return strings.TrimSuffix(name, ".go") + "_test.go"
+	}
+	return name
+}
+
+// generateBuilderCode generates builder or functional options code for a struct,
+// depending on the configured style
 func (g *Generator) generateBuilderCode(structInfo StructInfo) (string, error) {
-	// Create template data
+	text := BuilderTemplate
+	switch g.Options.Style {
+	case "", OutputStyleBuilder:
+	case OutputStyleOptions:
+		text = OptionsTemplate
+	case OutputStyleStep:
+		text = StepBuilderTemplate
+	case OutputStyleDeepCopy:
+		text = DeepCopyTemplate
+	default:
+		return "", fmt.Errorf("unknown style %q: must be %s, %s, %s or %s", g.Options.Style, OutputStyleBuilder, OutputStyleOptions, OutputStyleStep, OutputStyleDeepCopy)
+	}
+	if g.Options.Templates.Builder != "" {
+		text = g.Options.Templates.Builder
+	}
+
+	return g.render("builder", text, g.templateData(structInfo))
+}
+
+// generateExtraCode executes the extra per-struct templates for a struct
+func (g *Generator) generateExtraCode(structInfo StructInfo) ([]GeneratedFile, error) {
+	var files []GeneratedFile
+	for _, suffix := range g.Options.Templates.extraSuffixes() {
+		code, err := g.render(suffix, g.Options.Templates.Extra[suffix], g.templateData(structInfo))
+		if err != nil {
+			return nil, err
+		}
+		name := g.outputName(ToSnakeCase(structInfo.Name) + suffix)
+		content, err := formatSource(name, []byte(code))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, GeneratedFile{Name: name, Content: content})
+	}
+	return files, nil
+}
+
+// templateData returns the data per-struct templates are executed with
+func (g *Generator) templateData(structInfo StructInfo) interface{} {
 	data := struct {
 		PackageName   string
 		ModelsPackage string
 		Struct        StructInfo
 		ImportLines   string
+		ModelType     string
+		BuilderType   string
 	}{
-		PackageName:   g.Options.PackageName,
-		ModelsPackage: g.Options.ModelsPackage,
+		PackageName:   g.packageName(),
+		ModelsPackage: structInfo.ModelsPackage,
 		Struct:        structInfo,
 		ImportLines:   "",
+		ModelType:     structInfo.Name + structInfo.TypeArgs,
+		BuilderType:   structInfo.Name + "Builder" + structInfo.TypeArgs,
 	}
 
-	// Add required imports
-	imports := map[string]bool{
-		"fmt":                   true, // Always needed for error formatting
-		g.Options.ModelsPackage: true, // Always needed for model types
+	// Model types are qualified unless the builder is generated into the models package
+	imports := make(map[string]bool)
+	if structInfo.ModelsPackage != "" {
+		data.ModelType = structInfo.ModelsName + "." + data.ModelType
+		imports[structInfo.ModelsPackage] = true
 	}
 
-	// We don't actually need reflect for most builders
-	// Only add it if we're using it for specific functionality
-	// imports["reflect"] = true
-
-	// Add imports for field types
-	for _, field := range structInfo.Fields {
-		if field.ImportNeeded != "" && field.ImportNeeded != "models" {
-			imports[field.ImportNeeded] = true
+	switch g.Options.Style {
+	case OutputStyleOptions:
+		// Options only format errors for required and enum fields
+		if structInfo.HasRequired() || structInfo.HasEnums() {
+			imports["fmt"] = true
 		}
+	case OutputStyleDeepCopy:
+		// DeepCopy methods track the models they have copied with a builder.Copier
+		imports[BuilderPackage] = true
+	default:
+		// Builders and step builders format errors and copy the model they build with
+		// the runtime package
+		imports["fmt"] = true
+		imports[BuilderPackage] = true
 	}
 
-	// We don't need to automatically add the time package
-	// Only add it if we're actually using time.Time types
-	// imports["time"] = true
+	// Add imports for packages referenced by field types
+	for imp := range structInfo.Imports {
+		imports[imp] = true
+	}
 
 	// Convert map to sorted slice for consistent output
 	var importList []string
@@ -308,56 +972,13 @@ func (g *Generator) generateBuilderCode(structInfo StructInfo) (string, error) {
 		data.ImportLines += fmt.Sprintf("\t\"%s\"\n", imp)
 	}
 
-	// Parse template
-	tmpl, err := template.New("builder").Funcs(template.FuncMap{
-		"ToLowerFirst": ToLowerFirst,
-		"ToParamName":  ToParamName,
-		"replace":      strings.ReplaceAll,
-		"Singular":     ToSingular,
-		"base": func(path string) string {
-			// Extract the base package name from a full import path
-			parts := strings.Split(path, "/")
-			return parts[len(parts)-1]
-		},
-	}).Parse(BuilderTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %v", err)
-	}
-
-	// Execute template
-	var buf strings.Builder
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute template: %v", err)
-	}
-
-	return buf.String(), nil
+	return data
 }
 
-// isBuiltinType checks if a type is a built-in Go type
-func isBuiltinType(typeName string) bool {
-	builtinTypes := map[string]bool{
-		"string":     true,
-		"bool":       true,
-		"int":        true,
-		"int8":       true,
-		"int16":      true,
-		"int32":      true,
-		"int64":      true,
-		"uint":       true,
-		"uint8":      true,
-		"uint16":     true,
-		"uint32":     true,
-		"uint64":     true,
-		"uintptr":    true,
-		"byte":       true,
-		"rune":       true,
-		"float32":    true,
-		"float64":    true,
-		"complex64":  true,
-		"complex128": true,
-		"error":      true,
-	}
-	return builtinTypes[typeName]
+// baseName extracts the base package name from a full import path
+func baseName(path string) string {
+	parts := strings.Split(path, "/")
+	return parts[len(parts)-1]
 }
 
 // ToSnakeCase converts a string from CamelCase to snake_case
@@ -382,12 +1003,31 @@ func ToLowerFirst(s string) string {
 	return string(r)
 }
 
-// ToParamName converts a field name to a parameter name
-// Examples: ID -> id, UserID -> userID, HTTPRequest -> httpRequest
+// ToUpperFirst converts the first character of a string to uppercase
+func ToUpperFirst(s string) string {
+	if s == "" {
+		return ""
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// ToParamName converts a field name to a parameter name, avoiding keywords and
+// predeclared identifiers
+// Examples: ID -> id, UserID -> userID, HTTPRequest -> httpRequest, Type -> typeValue
 func ToParamName(s string) string {
 	if s == "" {
 		return ""
 	}
+	return SafeIdent(paramName(s), nil)
+}
+
+// paramName converts a field name to a parameter name
+func paramName(s string) string {
+	if s == "" {
+		return ""
+	}
 
 	// Special case for "ID" exactly
 	if s == "ID" {
@@ -0,0 +1,628 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const testModelsPackage = "github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/models"
+
+// testPackages shares loaded testdata packages between tests
+var testPackages = make(map[string][]*packages.Package)
+
+// generateFile runs the generator on a testdata file and returns the named output file
+func generateFile(t *testing.T, opts Options, inputFile, outputFile string) string {
+	t.Helper()
+
+	outputDir := t.TempDir()
+	gen := NewGenerator(opts)
+	gen.packages = testPackages
+	if err := gen.ProcessFile(inputFile, outputDir); err != nil {
+		t.Fatalf("ProcessFile failed: %v", err)
+	}
+
+	code, err := os.ReadFile(filepath.Join(outputDir, outputFile))
+	if err != nil {
+		t.Fatalf("Expected output file %s: %v", outputFile, err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), outputFile, code, 0); err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, code)
+	}
+	return string(code)
+}
+
+// assertContains fails the test for every expected snippet missing from code
+func assertContains(t *testing.T, code string, expected ...string) {
+	t.Helper()
+	for _, want := range expected {
+		if !strings.Contains(code, want) {
+			t.Errorf("Expected generated code to contain %q", want)
+		}
+	}
+}
+
+func TestProcessFileTypeKinds(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+
+	assertContains(t, code,
+		`"time"`,
+		"WithID(id ",
+		"WithStatus(status models.Status)",
+		"WithAddress(address *AddressBuilder)",
+		"WithHome(home *AddressBuilder)",
+		"WithCreatedAt(createdAt time.Time)",
+		"WithOwner(owner models.Named)",
+		"WithMeta(meta any)",
+		"WithFriends(friends []*PersonBuilder)",
+	)
+
+	for _, unexpected := range []string{"PersonIDBuilder", "StatusBuilder", "TimeBuilder", "NamedBuilder"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Expected no %s for non-struct or external types", unexpected)
+		}
+	}
+}
+
+func TestProcessFileStructTags(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "account_builder.go")
+
+	assertContains(t, code,
+		`b.model.Owner = "John Doe"`,
+		"b.model.Balance = 100.5",
+		`b.model.Status = "active"`,
+		"WithKeywords(tags []string)",
+		"WithHome(home *models.Address)",
+		`if account.ID == "" {`,
+		"if account.Home == nil {",
+		`fmt.Errorf("required field Owner is not set")`,
+	)
+
+	if strings.Contains(code, "Internal") {
+		t.Errorf("Expected field tagged builder:\"-\" to be skipped")
+	}
+}
+
+func TestParseFieldTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected FieldTag
+		wantErr  bool
+	}{
+		{tag: ``, expected: FieldTag{}},
+		{tag: `json:"id"`, expected: FieldTag{}},
+		{tag: `builder:"-"`, expected: FieldTag{Skip: true}},
+		{tag: `builder:"required,name=Identifier"`, expected: FieldTag{Required: true, Name: "Identifier"}},
+		{tag: `builder:"style=value"`, expected: FieldTag{Style: StyleValue}},
+		{tag: `builder:"required,default=a,b"`, expected: FieldTag{Required: true, Default: "a,b", HasDefault: true}},
+		{tag: `builder:"required, default=a,b"`, expected: FieldTag{Required: true, Default: "a,b", HasDefault: true}},
+		{tag: `builder:" name=Identifier , default=a, b"`, expected: FieldTag{Name: "Identifier", Default: "a, b", HasDefault: true}},
+		{tag: `builder:"enum=false"`, expected: FieldTag{NoEnum: true}},
+		{tag: `builder:"name=lower"`, wantErr: true},
+		{tag: `builder:"style=fancy"`, wantErr: true},
+		{tag: `builder:"optional"`, wantErr: true},
+		{tag: `builder:"enum=true"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFieldTag(tt.tag)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseFieldTag(%q) expected an error", tt.tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseFieldTag(%q) returned error: %v", tt.tag, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseFieldTag(%q) = %+v, expected %+v", tt.tag, got, tt.expected)
+		}
+	}
+}
+
+func TestProcessFileGenericStructs(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+
+	page := generateFile(t, opts, "testdata/models/models.go", "page_builder.go")
+	assertContains(t, page,
+		"type PageBuilder[T any] struct",
+		"func NewPageBuilder[T any]() *PageBuilder[T]",
+		"b := NewPageBuilder[T]()",
+		"func (b *PageBuilder[T]) WithItems(items []T) *PageBuilder[T]",
+		"func (b *PageBuilder[T]) WithNext(next *PageBuilder[T]) *PageBuilder[T]",
+		"func (b *PageBuilder[T]) BuildPtr() *models.Page[T]",
+		"func (b *PageBuilder[T]) Clone() builder.Builder[models.Page[T]]",
+		"func (b *PageBuilder[T]) CloneBuilder() *PageBuilder[T]",
+		"validationFuncs []func(*models.Page[T]) error",
+	)
+
+	stats := generateFile(t, opts, "testdata/models/models.go", "stats_builder.go")
+	assertContains(t, stats,
+		"type StatsBuilder[K comparable, V models.Number] struct",
+		"Key:   *new(K),",
+		"func (b *StatsBuilder[K, V]) WithPage(page *PageBuilder[V]) *StatsBuilder[K, V]",
+		"if reflect.ValueOf(&stats.Key).Elem().IsZero() {",
+	)
+}
+
+func TestProcessFileEmbeddedStructs(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+
+	document := generateFile(t, opts, "testdata/models/models.go", "document_builder.go")
+	assertContains(t, document,
+		`"github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/common"`,
+		"WithBaseEntity(baseEntity *BaseEntityBuilder)",
+		"WithCreatedAt(createdAt time.Time)",
+		"b.model.BaseEntity = &models.BaseEntity{}",
+		"b.model.BaseEntity.Audit = &models.Audit{}",
+		"b.model.BaseEntity.Audit.UpdatedBy = updatedBy",
+		"WithMeta(meta common.Meta)",
+		"b.model.Meta.Version = version",
+		"b.model.Meta.Owner = owner",
+		"WithID(id int)",
+		// Promoted fields set on the builder override the struct built by the embedded builder
+		"b.model.BaseEntity.CreatedAt = createdAt\n\tb.promoted[\"BaseEntity.CreatedAt\"] = true",
+		"document.BaseEntity = b.nestedBaseEntity.resolve(g)\n\t\tif b.promoted[\"BaseEntity.CreatedAt\"] {\n\t\t\tdocument.BaseEntity = builder.Detach(document.BaseEntity)\n\t\t\tdocument.BaseEntity.CreatedAt = p0.BaseEntity.CreatedAt",
+		"document.BaseEntity = builder.Detach(document.BaseEntity)\n\t\tdocument.BaseEntity.Audit = b.nestedAudit.resolve(g)",
+	)
+	for _, unexpected := range []string{"WithSecret", "BaseEntity.ID", "Audit.Owner"} {
+		if strings.Contains(document, unexpected) {
+			t.Errorf("Expected %s to be shadowed or inaccessible", unexpected)
+		}
+	}
+
+	comment := generateFile(t, opts, "testdata/models/models.go", "comment_builder.go")
+	assertContains(t, comment,
+		"b.model.BaseEntity.ID = id",
+		`if comment.BaseEntity == nil || comment.BaseEntity.ID == "" {`,
+	)
+
+	labelled := generateFile(t, opts, "testdata/models/models.go", "labelled_builder.go")
+	assertContains(t, labelled, "WithUpdatedBy(updatedBy string)", "WithVersion(version int)")
+	if strings.Contains(labelled, "WithOwner") {
+		t.Errorf("Expected ambiguous promoted field Owner to get no setter")
+	}
+}
+
+func TestProcessFileCollections(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "board_builder.go")
+
+	assertContains(t, code,
+		"WithAddresses(addresses []*AddressBuilder)",
+		"b.nestedAddresses = append([]*AddressBuilder{}, addresses...)",
+		"board.Addresses = make([]models.Address, len(b.nestedAddresses))",
+		"board.Addresses[k0] = *v0.resolve(g)",
+		"AddAddress(address *AddressBuilder)",
+		"WithScores(scores [3]int)",
+		"WithCorners(corners [4]*AddressBuilder)",
+		"b.nestedCorners = &corners",
+		"for k0, v0 := range *b.nestedCorners {",
+		"board.Corners[k0] = v0.resolve(g)",
+		"WithGrid(grid [][]string)",
+		"AddGrid(grid []string)",
+		"WithTaskGroups(key string, val []*TaskBuilder)",
+		"WithRows(rows []map[string]string)",
+		"AddRow(row map[string]string)",
+		"WithLanes(lanes [][]*TaskBuilder)",
+		"AddLane(lane []*TaskBuilder)",
+		"WithBacklog(key string, val map[string]*TaskBuilder)",
+	)
+
+	if strings.Contains(code, ".Build().(") {
+		t.Errorf("Expected nested builders to be converted without type assertions")
+	}
+	if strings.Contains(code, "AddScore") {
+		t.Errorf("Expected no Add helper for fixed-size arrays")
+	}
+}
+
+func TestProcessFileNamedCollections(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "catalog_builder.go")
+
+	assertContains(t, code,
+		"WithTags(tags models.Tags)",
+		"AddTag(tag string)",
+		"WithMetadata(key string, val string)",
+		"b.model.Metadata = make(models.Metadata)",
+		"PutMetadata(key string, val string)",
+		"WithMetadataMap(metadata models.Metadata)",
+		"WithTeam(team []*PersonBuilder)",
+		"catalog.Team = make(models.Team, len(b.nestedTeam))",
+		"AddTeam(team *PersonBuilder)",
+		"PutScore(key string, val int)",
+	)
+	if strings.Contains(code, "TagsBuilder") || strings.Contains(code, "MetadataBuilder") {
+		t.Errorf("Expected named collections not to be treated as nested builders")
+	}
+}
+
+func TestProcessFileEnums(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "account_builder.go")
+
+	assertContains(t, code,
+		"func (b *AccountBuilder) WithStatusActive() *AccountBuilder",
+		"return b.WithStatus(models.StatusActive)",
+		"func (b *AccountBuilder) WithStatusEnabled() *AccountBuilder",
+		"func (b *AccountBuilder) WithStatusSuspended() *AccountBuilder",
+		"return b.WithStatus(models.Suspended)",
+		"switch account.Status {",
+		// StatusEnabled has the value of StatusActive; unset optional fields are valid
+		`case models.StatusActive, models.StatusInactive, models.Suspended, "":`,
+		`fmt.Errorf("invalid value %v for field Status", account.Status)`,
+	)
+
+	opts.Style = OutputStyleStep
+	opts.Required = map[string][]string{"Person": {"Status"}}
+	code = generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+	assertContains(t, code,
+		"StatusActive() PersonOptionalStep",
+		"func (b *personStepBuilder) StatusActive() PersonOptionalStep",
+		"return b.Status(models.StatusActive)",
+		"case models.StatusActive, models.StatusInactive, models.Suspended:",
+	)
+}
+
+func TestGenerateEnumsOfModelsPackageOnly(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: virtualModelsPackage})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"job.go": `package virtual
+
+import "time"
+
+type Priority int
+
+const (
+	Low Priority = iota
+	High
+)
+
+// DefaultPriority is a constant of the type, but not one of its values
+const DefaultPriority = Low
+
+const Urgent Priority = 9
+
+type Job struct {
+	Timeout  time.Duration
+	Month    time.Month
+	Priority Priority
+}
+`},
+		Dir: "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	code := resultFiles(result)["job_builder.go"]
+	assertContains(t, code,
+		"func (b *JobBuilder) WithPriorityLow() *JobBuilder",
+		"case virtual.Low, virtual.High:",
+	)
+	for _, unexpected := range []string{"WithTimeoutHour", "WithMonthJanuary", "switch job.Timeout", "switch job.Month", "Urgent", "WithPriorityDefaultPriority"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Expected only the const block of a models type to be an enum, found %s", unexpected)
+		}
+	}
+}
+
+func TestGenerateEnumsNeedSeveralValues(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: virtualModelsPackage})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"share.go": `package virtual
+
+type Percent float64
+
+const MaxPercent Percent = 100
+
+type Level int
+
+const (
+	LevelLow Level = iota
+	LevelHigh
+)
+
+type Everything struct {
+	Share Percent
+	Level Level
+	Tier  Level ` + "`builder:\"enum=false\"`" + `
+}
+`},
+		Dir: "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	code := resultFiles(result)["everything_builder.go"]
+	assertContains(t, code,
+		"func (b *EverythingBuilder) WithLevelLow() *EverythingBuilder",
+		"switch everything.Level {",
+	)
+	// A single constant is a bound, not the set of values, and enum=false opts out
+	for _, unexpected := range []string{"WithShareMaxPercent", "switch everything.Share", "WithTierLow", "switch everything.Tier"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Expected no enum for Share or Tier, found %s", unexpected)
+		}
+	}
+}
+
+func TestProcessFileFieldDocs(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "contact_builder.go")
+
+	assertContains(t, code,
+		"// WithEmail sets the Email\n//\n// Email is the primary address.\n//\n// It is verified on sign-up.\nfunc",
+		"// WithType sets the Type\n//\n// Home, Work, etc.\nfunc",
+		"// WithMail sets the Mail\n//\n// Deprecated: use Email.\nfunc",
+		"// WithFax sets the Fax\n//\n// Fax numbers.\n//\n// Deprecated: nobody uses fax.\nfunc",
+		"// AddFax adds a single item to the Fax slice\n//\n// Deprecated: nobody uses fax.\nfunc",
+	)
+
+	opts.Style = OutputStyleStep
+	opts.Required = map[string][]string{"Contact": {"Mail"}}
+	code = generateFile(t, opts, "testdata/models/models.go", "contact_builder.go")
+	assertContains(t, code,
+		"\t// Deprecated: use Email.\n\tMail(mail string) ContactOptionalStep",
+		"\t// Deprecated: nobody uses fax.\n\tWithFax(fax []string) ContactOptionalStep",
+	)
+}
+
+func TestProcessFileLazyNestedBuilders(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "board_builder.go")
+
+	assertContains(t, code,
+		"nestedAddresses []*AddressBuilder",
+		"nestedCorners *[4]*AddressBuilder",
+		"nestedBacklog map[string]map[string]*TaskBuilder",
+		"func (b *BoardBuilder) AddAddress(address *AddressBuilder) *BoardBuilder {\n\tb.nestedAddresses = append(b.nestedAddresses, address)",
+		"board, _ := b.build(builder.NewGraph(false), false)",
+		"return b.build(builder.NewGraph(false), true)",
+		// Every builder is built once per build, so cycles between builders end
+		"board, built := builder.Enter(g, b, b.model)\n\tif built {\n\t\treturn board, nil\n\t}",
+		// Children are validated before the parent's own checks
+		"if validate {\n\t\t\tfor _, v0 := range b.nestedAddresses {\n\t\t\t\tif _, err := v0.build(g, true); err != nil {\n\t\t\t\t\treturn nil, fmt.Errorf(\"Addresses: %w\", err)",
+		"for _, v0 := range b.nestedBacklog {\n\t\t\t\tfor _, v1 := range v0 {",
+		"if !validate {\n\t\treturn board, nil\n\t}",
+	)
+	if strings.Contains(code, "b.model.Addresses") {
+		t.Errorf("Expected nested builders to be resolved at build time, not in setters")
+	}
+
+	person := generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+	assertContains(t, person,
+		"b.nestedAddress = address",
+		"if _, err := b.nestedAddress.build(g, true); err != nil {",
+		"person.Address = *b.nestedAddress.resolve(g)",
+		"person.Home = b.nestedHome.resolve(g)",
+	)
+}
+
+func TestProcessFileCopyOnBuild(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+
+	assertContains(t, code,
+		`"github.com/adil-faiyaz98/go-builder-kit/pkg/builder"`,
+		"person, _ := b.build(builder.NewGraph(false), false)",
+		"func (b *PersonBuilder) BuildShared() *models.Person {\n\tperson, _ := b.build(builder.NewGraph(true), false)",
+		"return b.build(builder.NewGraph(false), true)",
+	)
+
+	// Clone copies the model and nested builders with one Copier, keeping them aliased
+	assertContains(t, code,
+		"func (b *PersonBuilder) CloneBuilder() *PersonBuilder {",
+		"return builder.Copy(builder.NewCopier(), b)",
+		"func (b *PersonBuilder) CloneWith(c *builder.Copier) any {\n\tcloned := &PersonBuilder{}\n\tc.Record(b, cloned)",
+		"cloned.model = builder.Copy(c, b.model)",
+		"cloned.nestedAddress = builder.Copy(c, b.nestedAddress)",
+		"cloned.nestedFriends = builder.Copy(c, b.nestedFriends)",
+	)
+
+	opts.Style = OutputStyleOptions
+	code = generateFile(t, opts, "testdata/models/models.go", "person_options.go")
+	if strings.Contains(code, "pkg/builder") {
+		t.Errorf("Expected functional options not to import the runtime package")
+	}
+}
+
+func TestProcessFileBuilderInterface(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+
+	assertContains(t, code,
+		"var _ builder.Builder[models.Person] = (*PersonBuilder)(nil)",
+		"func (b *PersonBuilder) Build() models.Person {",
+		"return *person",
+		"func (b *PersonBuilder) Clone() builder.Builder[models.Person] {\n\treturn b.CloneBuilder()",
+	)
+	if strings.Contains(code, "Build() interface{}") {
+		t.Errorf("Expected Build to return the model type")
+	}
+
+	// Generic builders cannot be asserted without type arguments
+	page := generateFile(t, opts, "testdata/models/models.go", "page_builder.go")
+	assertContains(t, page, "func (b *PageBuilder[T]) Build() models.Page[T] {")
+	if strings.Contains(page, "var _ builder.Builder") {
+		t.Errorf("Expected no interface assertion for generic builders")
+	}
+}
+
+func TestTypeRefResolve(t *testing.T) {
+	task := &TypeRef{Shape: ShapeValue, Type: "models.Task", Kind: KindStruct, BuilderName: "TaskBuilder"}
+	taskPtr := &TypeRef{Shape: ShapePointer, Type: "*models.Task", Elem: task}
+	lanes := &TypeRef{Shape: ShapeSlice, Type: "[]*models.Task", Elem: taskPtr}
+	str := &TypeRef{Shape: ShapeValue, Type: "string", Kind: KindBasic}
+	groups := &TypeRef{Shape: ShapeMap, Type: "map[string][]*models.Task", Key: str, Elem: lanes}
+
+	if got := groups.ParamType(); got != "map[string][]*TaskBuilder" {
+		t.Errorf("ParamType() = %q", got)
+	}
+	if got := task.Resolve("dst", "v"); got != "dst = *v.resolve(g)" {
+		t.Errorf("Resolve() = %q", got)
+	}
+	if got := str.Resolve("dst", "src"); got != "dst = src" {
+		t.Errorf("Resolve() = %q", got)
+	}
+
+	expected := "dst = make(map[string][]*models.Task, len(src))\n\tfor k0, v0 := range src {\n\t\tdst[k0] = " +
+		"func(in1 []*TaskBuilder) []*models.Task { out1 := make([]*models.Task, len(in1)); for k1, v1 := range in1 { out1[k1] = v1.resolve(g) }; return out1 }(v0)\n\t}"
+	if got := groups.Resolve("dst", "src"); got != expected {
+		t.Errorf("Resolve() = %q, expected %q", got, expected)
+	}
+}
+
+func TestProcessFileMapHelpers(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage}
+	code := generateFile(t, opts, "testdata/models/models.go", "portfolio_builder.go")
+
+	assertContains(t, code,
+		"WithAllocation(key string, val float64)",
+		"PutAllocation(key string, val float64)",
+		"RemoveAllocation(key string)",
+		"WithAllocationMap(allocation map[string]float64)",
+		"MergeAllocation(allocation map[string]float64)",
+		"WithHoldings(key string, val *TaskBuilder)",
+		"b.nestedHoldings[key] = val",
+		"PutHolding(key string, val *TaskBuilder)",
+		"WithHoldingsMap(holdings map[string]*TaskBuilder)",
+		"b.nestedHoldings[k] = v",
+		"delete(b.nestedHoldings, key)",
+		"portfolio.Holdings[k0] = *v0.resolve(g)",
+		"WithWatchlist(key string, val *TaskBuilder)",
+		"portfolio.Watchlist[k0] = v0.resolve(g)",
+		"MergeWatchlist(watchlist map[string]*TaskBuilder)",
+	)
+}
+
+func TestProcessFileOptionsStyle(t *testing.T) {
+	opts := Options{PackageName: "builders", ModelsPackage: testModelsPackage, Style: OutputStyleOptions}
+
+	account := generateFile(t, opts, "testdata/models/models.go", "account_options.go")
+	assertContains(t, account,
+		"type AccountOption func(*models.Account)",
+		"func AccountID(id string) AccountOption",
+		"func AccountKeywords(tags []string) AccountOption",
+		"func NewAccount(opts ...AccountOption) (*models.Account, error)",
+		`account.Owner = "John Doe"`,
+		"opt(account)",
+		`fmt.Errorf("required field ID is not set")`,
+	)
+	if strings.Contains(account, "AccountBuilder") {
+		t.Errorf("Expected no builder in options style")
+	}
+
+	page := generateFile(t, opts, "testdata/models/models.go", "page_options.go")
+	assertContains(t, page,
+		"type PageOption[T any] func(*models.Page[T])",
+		"func PageItems[T any](items []T) PageOption[T]",
+		"func NewPage[T any](opts ...PageOption[T]) (*models.Page[T], error)",
+	)
+	if strings.Contains(page, `"fmt"`) {
+		t.Errorf("Expected fmt to be imported only when fields are required")
+	}
+}
+
+func TestProcessFileStepStyle(t *testing.T) {
+	opts := Options{
+		PackageName:   "builders",
+		ModelsPackage: testModelsPackage,
+		Style:         OutputStyleStep,
+		Required:      map[string][]string{"Person": {"Status"}},
+	}
+
+	account := generateFile(t, opts, "testdata/models/models.go", "account_builder.go")
+	assertContains(t, account,
+		"func NewAccountBuilder() AccountIDStep {",
+		"ID(id string) AccountOwnerStep",
+		"Owner(owner string) AccountHomeStep",
+		"Home(home *models.Address) AccountOptionalStep",
+		"WithKeywords(tags []string) AccountOptionalStep",
+		"func (b *accountStepBuilder) ID(id string) AccountOwnerStep {",
+		`b.model.Owner = "John Doe"`,
+		`fmt.Errorf("required field ID is not set")`,
+		// Every build returns a new copy of the model
+		"\tBuild() models.Account\n\tBuildPtr() *models.Account",
+		"func (b *accountStepBuilder) Build() models.Account {\n\treturn *builder.DeepCopy(b.model)",
+		"func (b *accountStepBuilder) BuildPtr() *models.Account {\n\treturn builder.DeepCopy(b.model)",
+		"account := builder.DeepCopy(b.model)",
+	)
+	if strings.Contains(account, "WithID(") {
+		t.Errorf("Expected required fields to be set only by their step")
+	}
+
+	// Required fields can also come from the options
+	person := generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+	assertContains(t, person,
+		"func NewPersonBuilder() PersonStatusStep {",
+		"Status(status models.Status) PersonOptionalStep",
+	)
+
+	stats := generateFile(t, opts, "testdata/models/models.go", "stats_builder.go")
+	assertContains(t, stats,
+		"type StatsKeyStep[K comparable, V models.Number] interface {",
+		"Key(key K) StatsValueStep[K, V]",
+		"func NewStatsBuilder[K comparable, V models.Number]() StatsKeyStep[K, V] {",
+	)
+
+	address := generateFile(t, opts, "testdata/models/models.go", "address_builder.go")
+	assertContains(t, address, "func NewAddressBuilder() AddressOptionalStep {")
+}
+
+func TestGenerateBuilderCodeUnknownStyle(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: testModelsPackage, Style: "fancy"})
+	if _, err := gen.generateBuilderCode(StructInfo{Name: "Person"}); err == nil {
+		t.Errorf("Expected an error for an unknown style")
+	}
+}
+
+func TestProcessFileFieldConfig(t *testing.T) {
+	opts := Options{
+		PackageName:   "builders",
+		ModelsPackage: testModelsPackage,
+		Required:      map[string][]string{"Address": {"City"}},
+		Fields: map[string]map[string]string{
+			"Address": {"Street": "name=Line1"},
+			"Person":  {"Meta": "-"},
+			"Account": {"Owner": "default=Jane"},
+		},
+	}
+
+	address := generateFile(t, opts, "testdata/models/models.go", "address_builder.go")
+	assertContains(t, address,
+		"func (b *AddressBuilder) WithLine1(street string) *AddressBuilder",
+		`fmt.Errorf("required field City is not set")`,
+	)
+
+	person := generateFile(t, opts, "testdata/models/models.go", "person_builder.go")
+	if strings.Contains(person, "WithMeta") {
+		t.Errorf("Expected Meta to be skipped by configuration")
+	}
+
+	// Configuration is applied on top of struct tags
+	account := generateFile(t, opts, "testdata/models/models.go", "account_builder.go")
+	assertContains(t, account,
+		`b.model.Owner = "Jane"`,
+		`fmt.Errorf("required field Owner is not set")`,
+	)
+
+	opts.Fields = map[string]map[string]string{"Address": {"City": "bogus"}}
+	gen := NewGenerator(opts)
+	gen.packages = testPackages
+	if err := gen.ProcessFile("testdata/models/models.go", t.TempDir()); err == nil {
+		t.Errorf("Expected an error for invalid field directives")
+	}
+}
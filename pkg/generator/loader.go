@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadMode is the set of information the generator needs from go/packages.
+// Dependencies are type-checked from source so that loading does not depend on
+// the export data format of the installed toolchain.
+const loadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedCompiledGoFiles |
+	packages.NeedImports |
+	packages.NeedTypes |
+	packages.NeedTypesInfo |
+	packages.NeedSyntax |
+	packages.NeedDeps
+
+// loadedFile is a parsed file together with the type-checked package it belongs to
+type loadedFile struct {
+	Package *packages.Package
+	File    *ast.File
+}
+
+// loadFile loads the package containing the given Go file with full type information
+// and returns the file's syntax tree. Packages are cached per directory so that
+// processing every file of a package only invokes the go tool once.
+func (g *Generator) loadFile(inputFile string) (*loadedFile, error) {
+	absFile, err := filepath.Abs(inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %v", inputFile, err)
+	}
+	dir := filepath.Dir(absFile)
+
+	if g.packages == nil {
+		g.packages = make(map[string][]*packages.Package)
+	}
+
+	if lf, err := findFile(g.packages[dir], absFile); lf != nil || err != nil {
+		return lf, err
+	}
+
+	pkgs, err := g.loadPackage(dir, absFile, g.overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package for %s: %v", inputFile, err)
+	}
+	lf, err := findFile(pkgs, absFile)
+
+	// Files this run regenerates may refer to the models as they were when they
+	// were written, so after a model changes they keep the package from
+	// type-checking. They are loaded without their declarations instead.
+	if err != nil {
+		if overlay, ok := g.regeneratedOverlay(dir); ok {
+			if pkgs, err = g.loadPackage(dir, absFile, overlay); err != nil {
+				return nil, fmt.Errorf("failed to load package for %s: %v", inputFile, err)
+			}
+			lf, err = findFile(pkgs, absFile)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	g.packages[dir] = append(g.packages[dir], pkgs...)
+	return lf, nil
+}
+
+// loadPackage loads the package of absFile, in dir, with the given overlay
+func (g *Generator) loadPackage(dir, absFile string, overlay map[string][]byte) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode:    loadMode,
+		Dir:     existingDir(dir),
+		Tests:   true,
+		Overlay: overlay,
+	}
+	return packages.Load(cfg, "file="+absFile)
+}
+
+// recordInput records inputFile as an input file of pkg
+func (g *Generator) recordInput(pkg *packages.Package, inputFile string) {
+	absFile, err := filepath.Abs(inputFile)
+	if err != nil {
+		return
+	}
+	if g.inputs == nil {
+		g.inputs = make(map[*packages.Package]map[string]bool)
+	}
+	if g.inputs[pkg] == nil {
+		g.inputs[pkg] = make(map[string]bool)
+	}
+	g.inputs[pkg][absFile] = true
+}
+
+// regeneratedOverlay returns the in-memory sources with every file builder-gen
+// generated into dir with the configured style and templates reduced to its
+// package clause, and whether there are any such files. Other generated files,
+// such as DeepCopy methods when generating builders, are kept, since hand-written
+// code of the package may use them.
+func (g *Generator) regeneratedOverlay(dir string) (map[string][]byte, bool) {
+	overlay := make(map[string][]byte, len(g.overlay))
+	sources := make(map[string][]byte)
+	for path, content := range g.overlay {
+		overlay[path] = content
+		if filepath.Dir(path) == dir {
+			sources[path] = content
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if _, ok := sources[path]; ok || entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		if content, err := os.ReadFile(path); err == nil {
+			sources[path] = content
+		}
+	}
+
+	found := false
+	for path, content := range sources {
+		if _, ok := g.structFileBase(filepath.Base(path)); !ok || !bytes.HasPrefix(content, []byte(generatedHeader)) {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, content, parser.PackageClauseOnly)
+		if err == nil {
+			overlay[path] = []byte("package " + f.Name.Name + "\n")
+			found = true
+		}
+	}
+	return overlay, found
+}
+
+// existingDir returns dir or, for in-memory sources in a directory that does
+// not exist on disk, its closest existing parent
+func existingDir(dir string) string {
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// findFile returns the file from the given packages, preferring the package
+// itself over its test variants. It returns nil if no package contains the file,
+// which happens when the file is excluded by build constraints.
+func findFile(pkgs []*packages.Package, absFile string) (*loadedFile, error) {
+	var found *loadedFile
+	for _, pkg := range pkgs {
+		for i, file := range pkg.CompiledGoFiles {
+			if file != absFile || i >= len(pkg.Syntax) {
+				continue
+			}
+			if found == nil || pkg.ID == pkg.PkgPath {
+				found = &loadedFile{Package: pkg, File: pkg.Syntax[i]}
+			}
+		}
+	}
+
+	if found != nil && len(found.Package.Errors) > 0 {
+		return nil, fmt.Errorf("failed to type-check package %s: %s", found.Package.PkgPath, packageErrors(found.Package))
+	}
+	return found, nil
+}
+
+// packageErrors joins the errors reported for a package into a single message
+func packageErrors(pkg *packages.Package) string {
+	var msgs []string
+	for _, err := range pkg.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
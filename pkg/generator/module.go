@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ImportPath returns the import path of the package in dir, computed from the
+// module path in the closest go.mod above it. The directory itself does not
+// have to exist yet, so it works for output directories that will be created.
+//
+// Within a go.work workspace, found like the go command does, the module must be
+// one the workspace uses, since the go command cannot build it otherwise.
+func ImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := closestModule(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := checkWorkspace(root); err != nil {
+		return "", err
+	}
+
+	gomod := filepath.Join(root, "go.mod")
+	content, err := os.ReadFile(gomod)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", gomod, err)
+	}
+	modulePath := modfile.ModulePath(content)
+	if modulePath == "" {
+		return "", fmt.Errorf("no module path in %s", gomod)
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return modulePath, nil
+	}
+	return modulePath + "/" + filepath.ToSlash(rel), nil
+}
+
+// closestModule returns the directory of the closest go.mod above dir
+func closestModule(dir string) (string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		gomod := filepath.Join(current, "go.mod")
+		if _, err := os.Stat(gomod); err == nil {
+			return current, nil
+		} else if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read %s: %v", gomod, err)
+		}
+
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+// checkWorkspace reports an error if the module in root belongs to a go.work
+// workspace that does not use it. The workspace file is taken from GOWORK or,
+// when that is unset, is the closest go.work above root; GOWORK=off disables
+// workspaces.
+func checkWorkspace(root string) error {
+	gowork := os.Getenv("GOWORK")
+	switch gowork {
+	case "off":
+		return nil
+	case "":
+		for current := root; ; current = filepath.Dir(current) {
+			path := filepath.Join(current, "go.work")
+			if _, err := os.Stat(path); err == nil {
+				gowork = path
+				break
+			}
+			if filepath.Dir(current) == current {
+				return nil
+			}
+		}
+	}
+
+	content, err := os.ReadFile(gowork)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", gowork, err)
+	}
+	work, err := modfile.ParseWork(gowork, content, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %v", gowork, err)
+	}
+	for _, use := range work.Use {
+		path := filepath.Clean(use.Path)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(gowork), path)
+		}
+		if path == root {
+			return nil
+		}
+	}
+	return fmt.Errorf("module %s is not used by the workspace %s", root, gowork)
+}
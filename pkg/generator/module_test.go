@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportPath(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	for dir, expected := range map[string]string{
+		"testdata/models":     testModelsPackage,
+		"testdata/not/yet":    "github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/not/yet",
+		"../..":               "github.com/adil-faiyaz98/go-builder-kit",
+		"testdata/models/../": "github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata",
+	} {
+		path, err := ImportPath(dir)
+		if err != nil {
+			t.Errorf("ImportPath(%s) failed: %v", dir, err)
+		} else if path != expected {
+			t.Errorf("ImportPath(%s) = %s, expected %s", dir, path, expected)
+		}
+	}
+
+	if _, err := ImportPath(t.TempDir()); err == nil {
+		t.Errorf("Expected an error outside a module")
+	}
+}
+
+func TestImportPathWorkspace(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"go.work":               "go 1.22\n\nuse (\n\t./app\n\t./app/models\n)\n",
+		"app/go.mod":            "module example.com/app\n",
+		"app/models/go.mod":     "module example.com/models\n",
+		"app/vendored/x/go.mod": "module example.com/x\n",
+		"other/go.mod":          "module example.com/other\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Directories belong to the closest module the workspace uses
+	for dir, expected := range map[string]string{
+		"app":               "example.com/app",
+		"app/builders":      "example.com/app/builders",
+		"app/models/person": "example.com/models/person",
+	} {
+		path, err := ImportPath(filepath.Join(root, filepath.FromSlash(dir)))
+		if err != nil {
+			t.Errorf("ImportPath(%s) failed: %v", dir, err)
+		} else if path != expected {
+			t.Errorf("ImportPath(%s) = %s, expected %s", dir, path, expected)
+		}
+	}
+	for _, dir := range []string{"other", "app/vendored/x/inner"} {
+		if _, err := ImportPath(filepath.Join(root, filepath.FromSlash(dir))); err == nil {
+			t.Errorf("Expected an error for %s, in a module the workspace does not use", dir)
+		}
+	}
+
+	// GOWORK=off ignores the workspace
+	t.Setenv("GOWORK", "off")
+	if path, err := ImportPath(filepath.Join(root, "app/vendored/x/inner")); err != nil || path != "example.com/x/inner" {
+		t.Errorf("ImportPath without workspace = %s, %v, expected example.com/x/inner", path, err)
+	}
+}
@@ -0,0 +1,216 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// builderMethods are the methods every builder declares besides its field setters
+var builderMethods = []string{"WithValidation", "Build", "BuildPtr", "BuildShared", "BuildAndValidate", "MustBuild", "Clone", "CloneBuilder", "CloneWith"}
+
+// stepMethods are the methods every step builder declares besides its field setters
+var stepMethods = []string{"WithValidation", "Build", "BuildPtr", "BuildAndValidate", "MustBuild"}
+
+// SafeIdent returns name, suffixed with "Value" when it is a Go keyword or
+// predeclared identifier (type, func, range, string, len, ...) or one of reserved
+func SafeIdent(name string, reserved map[string]bool) string {
+	for token.IsKeyword(name) || types.Universe.Lookup(name) != nil || reserved[name] {
+		name += "Value"
+	}
+	return name
+}
+
+// accessible reports whether generated code can refer to the field or type
+// name obj: exported names always, unexported ones only from the models package
+func (r *typeResolver) accessible(obj types.Object) bool {
+	return obj.Exported() || (r.modelsName == "" && obj.Pkg() == r.pkg)
+}
+
+// accessibleType reports whether generated code can spell out t, which is not
+// the case for unexported types of other packages
+func (r *typeResolver) accessibleType(t types.Type) bool {
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return r.accessibleType(t.Elem())
+	case *types.Slice:
+		return r.accessibleType(t.Elem())
+	case *types.Array:
+		return r.accessibleType(t.Elem())
+	case *types.Chan:
+		return r.accessibleType(t.Elem())
+	case *types.Map:
+		return r.accessibleType(t.Key()) && r.accessibleType(t.Elem())
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+			for i := 0; i < tuple.Len(); i++ {
+				if !r.accessibleType(tuple.At(i).Type()) {
+					return false
+				}
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if !r.accessible(t.Field(i)) || !r.accessibleType(t.Field(i).Type()) {
+				return false
+			}
+		}
+	case *types.Named:
+		if t.Obj().Pkg() != nil && !r.accessible(t.Obj()) {
+			return false
+		}
+		args := t.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if !r.accessibleType(args.At(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fieldNames returns the names generated for a field in the given style: methods
+// of the builder, and declarations at package level
+func fieldNames(structName string, field StructField, style string) (methods, decls []string) {
+	name := field.MethodName
+	switch style {
+	case OutputStyleOptions:
+		decls = append(decls, structName+name)
+		for _, v := range field.Enum {
+			decls = append(decls, structName+name+v.Name)
+		}
+	case OutputStyleStep:
+		prefix := "With"
+		if field.Required {
+			prefix = ""
+			decls = append(decls, structName+name+"Step")
+		}
+		methods = append(methods, prefix+name)
+		for _, v := range field.Enum {
+			methods = append(methods, prefix+name+v.Name)
+		}
+	default:
+		methods = append(methods, "With"+name)
+		for _, v := range field.Enum {
+			methods = append(methods, "With"+name+v.Name)
+		}
+		if field.IsSlice {
+			methods = append(methods, "Add"+ToSingular(name))
+		}
+		if field.IsMap {
+			methods = append(methods, "Put"+ToSingular(name), "Remove"+ToSingular(name), "With"+name+"Map", "Merge"+name)
+		}
+	}
+	return methods, decls
+}
+
+// resolveNames gives every field of a struct setter names that do not collide
+// with the generated methods and declarations or with each other, and parameter
+// names that are valid identifiers and shadow nothing the generated code uses.
+// It also names the model variable of the generated code and, with it, the zero
+// checks of required fields.
+//
+// Collisions are resolved in declaration order: a field whose names are taken is
+// renamed to <Name>Field, then <Name>Field2 and so on, and a warning is recorded.
+func (g *Generator) resolveNames(resolver *typeResolver, info *StructInfo) {
+	methods := make(map[string]bool)
+	decls := make(map[string]bool)
+	switch g.Options.Style {
+	case OutputStyleOptions:
+		decls[info.Name+"Option"] = true
+		decls["New"+info.Name] = true
+	case OutputStyleStep:
+		for _, m := range stepMethods {
+			methods[m] = true
+		}
+		decls[info.Name+"OptionalStep"] = true
+		decls["New"+info.Name+"Builder"] = true
+	default:
+		for _, m := range builderMethods {
+			methods[m] = true
+		}
+	}
+
+	// Packages and type parameters referenced by the generated code must not be
+	// shadowed
+	outer := map[string]bool{"fmt": true, "reflect": true, baseName(BuilderPackage): true, resolver.modelsName: true}
+	for name := range resolver.qualifiers {
+		outer[name] = true
+	}
+	for _, tp := range strings.Split(strings.Trim(info.TypeArgs, "[]"), ",") {
+		outer[strings.TrimSpace(tp)] = true
+	}
+
+	// The model variable, named after the struct, must neither shadow them nor
+	// be shadowed by the other variables of the build and validation code, and
+	// must leave the constants of enum checks visible in the models package
+	locals := map[string]bool{"b": true, "g": true, "validate": true, "built": true, "p0": true, "err": true, "v": true, "ok": true, "validationFunc": true, "opt": true, "opts": true, "model": true}
+	for name := range outer {
+		locals[name] = true
+	}
+	if resolver.modelsName == "" {
+		for _, name := range resolver.pkg.Scope().Names() {
+			locals[name] = true
+		}
+	}
+	info.VarName = SafeIdent(ToLowerFirst(info.Name), locals)
+	for i := range info.Fields {
+		field := &info.Fields[i]
+		if !field.Required {
+			continue
+		}
+		var guards string
+		for _, init := range field.EmbedInits {
+			guards += info.VarName + "." + init.Path + " == nil || "
+		}
+		field.ZeroCheck = guards + resolver.zeroCheck(field.typ, info.VarName+"."+field.Path)
+	}
+
+	// Parameters must not shadow the receiver or the model variable of functional
+	// options either
+	params := map[string]bool{"b": true, info.VarName: true}
+	for name := range outer {
+		params[name] = true
+	}
+
+	for i := range info.Fields {
+		field := &info.Fields[i]
+		base := field.MethodName
+		for n := 1; ; n++ {
+			fieldMethods, fieldDecls := fieldNames(info.Name, *field, g.Options.Style)
+			if !anyTaken(methods, fieldMethods) && !anyTaken(decls, fieldDecls) {
+				markTaken(methods, fieldMethods)
+				markTaken(decls, fieldDecls)
+				break
+			}
+			field.MethodName = base + "Field"
+			if n > 1 {
+				field.MethodName += fmt.Sprint(n)
+			}
+		}
+		if field.MethodName != base {
+			g.warnf("%s: setters for field %s are named after %s to avoid a collision with generated code", info.Name, field.Path, field.MethodName)
+		}
+
+		field.ParamName = SafeIdent(ToParamName(field.Name), params)
+		field.ItemName = SafeIdent(ToParamName(ToSingular(field.Name)), params)
+	}
+}
+
+// anyTaken reports whether any of names is in taken
+func anyTaken(taken map[string]bool, names []string) bool {
+	for _, name := range names {
+		if taken[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// markTaken adds names to taken
+func markTaken(taken map[string]bool, names []string) {
+	for _, name := range names {
+		taken[name] = true
+	}
+}
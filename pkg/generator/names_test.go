@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+const reservedModels = `package virtual
+
+import "time"
+
+type base struct {
+	ID     string
+	secret string
+}
+
+type Reserved struct {
+	base
+	Type       string
+	Func       func()
+	Range      []int
+	Map        map[string]int
+	Default    bool
+	String     string
+	Len        int
+	B          int
+	Time       time.Time
+	Virtual    string
+	Reserved   string
+	Validation string
+	Tags       map[string]string
+	TagsMap    string
+	Option     string
+	Status     status
+	hidden     int
+}
+
+type status int
+
+type Package struct {
+	Name string
+	Kind Kind
+}
+
+type Kind int
+
+const (
+	KindLibrary Kind = iota
+	KindCommand
+)
+
+type Type struct {
+	Package *Package
+}
+
+type Time struct {
+	At time.Time
+}
+
+type Fmt struct {
+	Name string
+}
+
+type Builder struct {
+	Name string
+}
+`
+
+func TestSafeIdent(t *testing.T) {
+	tests := map[string]string{
+		"name":    "name",
+		"type":    "typeValue",
+		"func":    "funcValue",
+		"range":   "rangeValue",
+		"string":  "stringValue",
+		"len":     "lenValue",
+		"nil":     "nilValue",
+		"b":       "bValue",
+		"default": "defaultValue",
+	}
+	reserved := map[string]bool{"b": true}
+	for name, expected := range tests {
+		if got := SafeIdent(name, reserved); got != expected {
+			t.Errorf("SafeIdent(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+	if got := ToParamName("Range"); got != "rangeValue" {
+		t.Errorf("ToParamName(Range) = %q, expected rangeValue", got)
+	}
+}
+
+func TestReservedNames(t *testing.T) {
+	for _, style := range []string{OutputStyleBuilder, OutputStyleOptions, OutputStyleStep} {
+		gen := NewGenerator(Options{
+			PackageName:   "builders",
+			ModelsPackage: virtualModelsPackage,
+			Style:         style,
+			Required: map[string][]string{
+				"Reserved": {"Type", "Validation"},
+				"Package":  {"Name"},
+				"Type":     {"Package"},
+				"Time":     {"At"},
+				"Fmt":      {"Name"},
+				"Builder":  {"Name"},
+			},
+		})
+		result, err := gen.Generate(Input{
+			Sources: map[string]string{"models.go": reservedModels},
+			Dir:     "testdata/virtual",
+		})
+		if err != nil {
+			t.Fatalf("Generate(%s) failed: %v", style, err)
+		}
+
+		files := resultFiles(result)
+		if err := result.Verify(""); err != nil {
+			t.Errorf("Generated %s code does not compile: %v", style, err)
+		}
+		for name, content := range files {
+			if strings.Contains(content, "secret") || strings.Contains(content, "hidden") || strings.Contains(content, "Status") {
+				t.Errorf("Expected no setters for unexported fields or types in %s:\n%s", name, content)
+			}
+		}
+
+		switch style {
+		case OutputStyleBuilder:
+			assertContains(t, files["reserved_builder.go"],
+				"WithID(id string)",
+				"b.model.ID = id",
+				"WithType(typeValue string)",
+				"WithFunc(funcValue func())",
+				"WithRange(rangeValue []int)",
+				"AddRange(rangeValue int)",
+				"WithDefault(defaultValue bool)",
+				"WithString(stringValue string)",
+				"WithLen(lenValue int)",
+				"WithB(bValue int)",
+				"WithTime(timeValue time.Time)",
+				"WithVirtual(virtualValue string)",
+				"WithReserved(reservedValue string)",
+				"WithValidationField(validation string)",
+				"WithTagsMap(tags map[string]string)",
+				"WithTagsMapField(tagsMap string)",
+				"WithOption(option string)",
+			)
+			assertContains(t, files["package_builder.go"],
+				"b := NewPackageBuilder()",
+				"packageValue, built := builder.Enter(g, b, b.model)",
+				`if packageValue.Name == "" {`,
+				"switch packageValue.Kind {",
+			)
+			assertContains(t, files["type_builder.go"], "if typeValue.Package == nil {")
+			assertContains(t, files["time_builder.go"], "if timeValue.At == (time.Time{}) {")
+			assertContains(t, files["fmt_builder.go"], "fmtValue, built := builder.Enter(g, b, b.model)")
+			assertContains(t, files["builder_builder.go"], "builderValue, built := builder.Enter(g, b, b.model)")
+			if len(result.Warnings) != 3 {
+				t.Errorf("Expected warnings for the Status type and two renamed fields, got %v", result.Warnings)
+			}
+		case OutputStyleOptions:
+			assertContains(t, files["reserved_options.go"],
+				"func ReservedReserved(reservedValue string) ReservedOption",
+				"func ReservedValidation(validation string) ReservedOption",
+				"func ReservedOptionField(option string) ReservedOption",
+			)
+			assertContains(t, files["package_options.go"],
+				"return func(packageValue *virtual.Package) {",
+				"packageValue := &virtual.Package{",
+			)
+		case OutputStyleStep:
+			assertContains(t, files["reserved_builder.go"],
+				"Type(typeValue string) ReservedValidationStep",
+				"Validation(validation string) ReservedOptionalStep",
+				"WithMap(mapValue map[string]int) ReservedOptionalStep",
+			)
+			assertContains(t, files["package_builder.go"], "packageValue := builder.DeepCopy(b.model)")
+		}
+	}
+}
+
+func TestModelsNamedAfterBuilderInternals(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: virtualModelsPackage})
+	result, err := gen.Generate(Input{
+		Sources: map[string]string{"models.go": `package virtual
+
+type Part struct {
+	Name string
+}
+
+type Build struct {
+	Part *Part
+}
+
+type Resolve struct {
+	Part *Part
+}
+
+type ValidationFuncs struct {
+	Name string
+}
+
+type NestedParts struct {
+	Parts []*Part
+}
+
+type Item struct {
+	Parts []*Part
+	Index map[string]*Part
+}
+`},
+		Dir: "testdata/virtual",
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := result.Verify(""); err != nil {
+		t.Errorf("Generated code does not compile: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrOutOfDate is returned by DiffSink when generated files differ from the output directory
+var ErrOutOfDate = errors.New("generated files are out of date")
+
+// Sink receives generated files, for example to write them to disk
+type Sink interface {
+	Write(result *Result) error
+}
+
+// DirSink writes generated files to a directory, creating it if needed. Nothing
+// is written if the files do not compile together with the rest of the directory.
+// Files generated before generation switched to or from test files, such as
+// person_builder.go for person_builder_test.go, are removed.
+type DirSink struct {
+	Dir string
+}
+
+// Write implements Sink
+func (s DirSink) Write(result *Result) error {
+	if err := result.Verify(s.Dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %v", s.Dir, err)
+	}
+	for _, file := range result.Files {
+		path := filepath.Join(s.Dir, file.Name)
+		if err := os.WriteFile(path, file.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+	}
+	return removeReplaced(s.Dir, result.Files)
+}
+
+// WriterSink writes generated files to a writer, such as os.Stdout, each
+// preceded by a comment naming the file
+type WriterSink struct {
+	W io.Writer // Defaults to os.Stdout
+}
+
+// Write implements Sink
+func (s WriterSink) Write(result *Result) error {
+	w := s.W
+	if w == nil {
+		w = os.Stdout
+	}
+	for _, file := range result.Files {
+		if _, err := fmt.Fprintf(w, "// %s\n%s\n", file.Name, file.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ZipSink writes generated files to a zip archive
+type ZipSink struct {
+	W io.Writer
+}
+
+// Write implements Sink
+func (s ZipSink) Write(result *Result) error {
+	archive := zip.NewWriter(s.W)
+	for _, file := range result.Files {
+		w, err := archive.Create(file.Name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %v", file.Name, err)
+		}
+		if _, err := w.Write(file.Content); err != nil {
+			return fmt.Errorf("failed to add %s to archive: %v", file.Name, err)
+		}
+	}
+	return archive.Close()
+}
+
+// DiffSink compares generated files with a directory instead of writing them. It
+// prints a unified diff for every missing, out-of-date or stale file and returns
+// an error wrapping ErrOutOfDate if there are any.
+type DiffSink struct {
+	Dir string
+	W   io.Writer // Defaults to os.Stdout
+}
+
+// Write implements Sink
+func (s DiffSink) Write(result *Result) error {
+	w := s.W
+	if w == nil {
+		w = os.Stdout
+	}
+
+	stale, err := result.StaleFiles(s.Dir)
+	if err != nil {
+		return err
+	}
+	diffs, err := CheckFiles(s.Dir, result.Files, stale...)
+	if err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		if _, err := io.WriteString(w, diff); err != nil {
+			return err
+		}
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%w: %d file(s) in %s", ErrOutOfDate, len(diffs), s.Dir)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Setter styles that can be forced with the style tag option
+const (
+	// StyleValue generates a plain setter taking the field's type
+	StyleValue = "value"
+	// StyleBuilder generates a setter taking the nested struct's builder
+	StyleBuilder = "builder"
+)
+
+// FieldTag holds the directives of a `builder:"..."` struct tag.
+//
+// Options are separated by commas:
+//
+//	builder:"-"                    skip the field
+//	builder:"required"             fail BuildAndValidate if the field is zero
+//	builder:"name=Identifier"      generate WithIdentifier instead of With<Field>
+//	builder:"style=value"          force a plain setter (or style=builder for a nested builder)
+//	builder:"enum=false"           no enum setters or validation for a type with declared constants
+//	builder:"default=John Doe"     value set by New<T>BuilderWithDefaults
+//
+// Because default values may contain commas, default must be the last option.
+type FieldTag struct {
+	Skip       bool
+	Required   bool
+	Name       string
+	Style      string
+	NoEnum     bool
+	Default    string
+	HasDefault bool
+}
+
+// ParseFieldTag parses the builder directives from a raw struct tag
+func ParseFieldTag(tag string) (FieldTag, error) {
+	var ft FieldTag
+
+	value, ok := reflect.StructTag(tag).Lookup("builder")
+	if !ok {
+		return ft, nil
+	}
+	return ParseFieldOptions(value)
+}
+
+// ParseFieldOptions parses builder directives without the surrounding struct
+// tag, as used by configuration files
+func ParseFieldOptions(value string) (FieldTag, error) {
+	var ft FieldTag
+	if value == "" {
+		return ft, nil
+	}
+
+	if value == "-" {
+		ft.Skip = true
+		return ft, nil
+	}
+
+	for value = strings.TrimLeft(value, " "); value != ""; value = strings.TrimLeft(value, " ") {
+		var option string
+		if strings.HasPrefix(value, "default=") {
+			// The default value runs to the end of the tag
+			option, value = value, ""
+		} else if i := strings.Index(value, ","); i >= 0 {
+			option, value = value[:i], value[i+1:]
+		} else {
+			option, value = value, ""
+		}
+
+		key, arg, hasArg := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "required":
+			ft.Required = true
+		case "name":
+			if !hasArg || !isExportedIdent(arg) {
+				return ft, fmt.Errorf("invalid name option %q: must be an exported identifier", option)
+			}
+			ft.Name = arg
+		case "style":
+			if arg != StyleValue && arg != StyleBuilder {
+				return ft, fmt.Errorf("invalid style option %q: must be %s or %s", option, StyleValue, StyleBuilder)
+			}
+			ft.Style = arg
+		case "enum":
+			if arg != "false" {
+				return ft, fmt.Errorf("invalid enum option %q: only enum=false is supported", option)
+			}
+			ft.NoEnum = true
+		case "default":
+			ft.Default = strings.TrimPrefix(option, "default=")
+			ft.HasDefault = true
+		default:
+			return ft, fmt.Errorf("unknown option %q", option)
+		}
+	}
+
+	return ft, nil
+}
+
+// merge returns the tag with the directives set in override applied on top
+func (ft FieldTag) merge(override FieldTag) FieldTag {
+	ft.Skip = ft.Skip || override.Skip
+	ft.Required = ft.Required || override.Required
+	ft.NoEnum = ft.NoEnum || override.NoEnum
+	if override.Name != "" {
+		ft.Name = override.Name
+	}
+	if override.Style != "" {
+		ft.Style = override.Style
+	}
+	if override.HasDefault {
+		ft.Default, ft.HasDefault = override.Default, true
+	}
+	return ft
+}
+
+// isExportedIdent reports whether s is a valid exported Go identifier
+func isExportedIdent(s string) bool {
+	if s == "" || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
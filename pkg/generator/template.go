@@ -2,143 +2,359 @@ package generator
 
 // BuilderTemplate is the template for generating builder code
 const BuilderTemplate = `package {{ .PackageName }}
+{{- $model := "b.model" }}
 
 import (
 {{ .ImportLines }})
 
 // {{ .Struct.Name }}Builder builds a {{ .Struct.Name }} model
-type {{ .Struct.Name }}Builder struct {
-	{{ ToLowerFirst .Struct.Name }} *{{ .ModelsPackage | base }}.{{ .Struct.Name }}
+type {{ .Struct.Name }}Builder{{ .Struct.TypeParams }} struct {
+	model *{{ $.ModelType }}
 	// Custom validation functions
-	validationFuncs []func(*{{ .ModelsPackage | base }}.{{ .Struct.Name }}) error
+	validationFuncs []func(*{{ $.ModelType }}) error
+	{{- if .Struct.HasOverrides }}
+	// Promoted fields set on the builder, applied over embedded structs built by nested builders
+	promoted map[string]bool
+	{{- end }}
+	{{- range .Struct.Fields }}
+	{{- if .Ref.HasBuilder }}
+	// Nested builders for {{ .Name }}, resolved when the {{ $.Struct.Name }} is built
+	nested{{ .MethodName }} {{ if .IsArray }}*{{ end }}{{ .Ref.ParamType }}
+	{{- end }}
+	{{- end }}
 }
+{{- if not .Struct.TypeParams }}
+
+// {{ .Struct.Name }}Builder implements builder.Builder for {{ .Struct.Name }}
+var _ builder.Builder[{{ $.ModelType }}] = (*{{ $.BuilderType }})(nil)
+{{- end }}
 
 // New{{ .Struct.Name }}Builder creates a new {{ .Struct.Name }}Builder
-func New{{ .Struct.Name }}Builder() *{{ .Struct.Name }}Builder {
-	return &{{ .Struct.Name }}Builder{
-		{{ ToLowerFirst .Struct.Name }}: &{{ .ModelsPackage | base }}.{{ .Struct.Name }}{
+func New{{ .Struct.Name }}Builder{{ .Struct.TypeParams }}() *{{ $.BuilderType }} {
+	return &{{ $.BuilderType }}{
+		model: &{{ $.ModelType }}{
 			{{- range .Struct.Fields }}
-			{{ .Name }}: {{ if .IsSlice }}{{ if .IsNested }}[]*{{ $.ModelsPackage | base }}.{{ .ElementType }}{}{{ else }}{{ .Type }}{}{{ end }}{{ else if .IsMap }}map[{{ .KeyType }}]{{ .ValType }}{}{{ else if .IsPointer }}{{ if .IsNested }}nil{{ else }}nil{{ end }}{{ else }}{{ if eq .Type "string" }}""{{ else if eq .Type "int" }}0{{ else if eq .Type "float64" }}0.0{{ else if eq .Type "bool" }}false{{ else if .IsBuiltin }}{{ .Type }}(0){{ else }}{{ $.ModelsPackage | base }}.{{ .Type }}{}{{ end }}{{ end }},
+			{{- if not .IsPromoted }}
+			{{ .Name }}: {{ .ZeroValue }},
+			{{- end }}
 			{{- end }}
 		},
-		validationFuncs: []func(*{{ .ModelsPackage | base }}.{{ .Struct.Name }}) error{},
+		validationFuncs: []func(*{{ $.ModelType }}) error{},
+		{{- if .Struct.HasOverrides }}
+		promoted:        map[string]bool{},
+		{{- end }}
 	}
 }
 
 // New{{ .Struct.Name }}BuilderWithDefaults creates a new {{ .Struct.Name }}Builder with sensible defaults
-func New{{ .Struct.Name }}BuilderWithDefaults() *{{ .Struct.Name }}Builder {
-	builder := New{{ .Struct.Name }}Builder()
+func New{{ .Struct.Name }}BuilderWithDefaults{{ .Struct.TypeParams }}() *{{ $.BuilderType }} {
+	b := New{{ .Struct.Name }}Builder{{ .Struct.TypeArgs }}()
+	{{- if .Struct.HasDefaults }}
+	{{- range .Struct.Fields }}
+	{{- if .Default }}
+	{{- range .EmbedInits }}
+	if b.model.{{ .Path }} == nil {
+		b.model.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	b.model.{{ .Path }} = {{ .Default }}
+	{{- end }}
+	{{- end }}
+	{{- else }}
 	// Add default values here if needed
-	return builder
+	{{- end }}
+	return b
 }
 
 {{- range .Struct.Fields }}
-// With{{ .Name }} sets the {{ .Name }}
-func (b *{{ $.Struct.Name }}Builder) With{{ .Name }}({{ if eq (ToLowerFirst .Name) "type" }}value {{ .Type }}{{ else }}{{ if .IsMap }}key {{ .KeyType }}, val {{ .ValType }}{{ else }}{{ ToParamName .Name }} {{ if .IsSlice }}{{ if .IsNested }}[]*{{ .BuilderName }}{{ else }}{{ .Type }}{{ end }}{{ else if .IsPointer }}{{ if .IsNested }}*{{ .BuilderName }}{{ else }}*{{ .Type }}{{ end }}{{ else if .IsNested }}*{{ .BuilderName }}{{ else }}{{ .Type }}{{ end }}{{ end }}{{ end }}) *{{ $.Struct.Name }}Builder {
-	{{- if and .IsSlice .IsNested }}
-	// Ensure the slice is initialized
-	if b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} == nil {
-		b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = []*{{ $.ModelsPackage | base }}.{{ .ElementType }}{}
-	}
-	{{- end }}
-	{{- if .IsSlice }}
-	{{- if .IsNested }}
-	// Handle nested slice elements
-	// Initialize the slice
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = make([]*{{ $.ModelsPackage | base }}.{{ .ElementType }}, 0, len({{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}))
-	// Convert each builder to its model
-	for _, builder := range {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }} {
-		builtValue := builder.Build().(*{{ $.ModelsPackage | base }}.{{ .ElementType }})
-		b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = append(b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }}, builtValue)
+{{- $param := .ParamName }}
+{{- $field := printf "%s.%s" $model .Path }}
+{{- $nested := printf "b.nested%s" .MethodName }}
+
+// With{{ .MethodName }} sets the {{ .Name }}{{ .DocComment }}
+func (b *{{ $.BuilderType }}) With{{ .MethodName }}({{ if .IsMap }}key {{ .Ref.Key.Type }}, val {{ .Ref.Elem.ParamType }}{{ else }}{{ $param }} {{ .Ref.ParamType }}{{ end }}) *{{ $.BuilderType }} {
+	{{- range .EmbedInits }}
+	// Allocate embedded {{ .Path }} so the promoted field can be set
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
 	}
+	{{- end }}
+	{{- if and .IsMap .Ref.HasBuilder }}
+	if {{ $nested }} == nil {
+		{{ $nested }} = make({{ .Ref.ParamType }})
+	}
+	{{ $nested }}[key] = val
+	{{- else if .IsMap }}
+	if {{ $field }} == nil {
+		{{ $field }} = make({{ .Type }})
+	}
+	{{ $field }}[key] = val
+	{{- else if and .IsSlice .Ref.HasBuilder }}
+	{{ $nested }} = append({{ .Ref.ParamType }}{}, {{ $param }}...)
+	{{- else if .IsSlice }}
+	{{ $field }} = append({{ $field }}, {{ $param }}...)
+	{{- else if and .IsArray .Ref.HasBuilder }}
+	{{ $nested }} = &{{ $param }}
+	{{- else if .Ref.HasBuilder }}
+	{{ $nested }} = {{ $param }}
 	{{- else }}
-	{{- if eq .Type "[]string" }}
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = append(b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }}, {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}...)
-	{{- else }}
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = append(b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }}, {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}...)
+	{{ $field }} = {{ $param }}
+	{{- if .IsOverride }}
+	b.promoted["{{ .Path }}"] = true
 	{{- end }}
 	{{- end }}
-	{{- else if .IsMap }}
-	if b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} == nil {
-		b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = make(map[{{ .KeyType }}]{{ .ValType }})
+	return b
+}
+{{- end }}
+
+{{- range .Struct.Fields }}
+{{- $field := . }}
+{{- range .Enum }}
+
+// With{{ $field.MethodName }}{{ .Name }} sets the {{ $field.Name }} to {{ .Const }}{{ $field.DeprecatedComment }}
+func (b *{{ $.BuilderType }}) With{{ $field.MethodName }}{{ .Name }}() *{{ $.BuilderType }} {
+	return b.With{{ $field.MethodName }}({{ .Value }})
+}
+{{- end }}
+{{- end }}
+
+{{- range .Struct.Fields }}
+{{- if .IsSlice }}
+{{- $item := .ItemName }}
+{{- $field := printf "%s.%s" $model .Path }}
+{{- $nested := printf "b.nested%s" .MethodName }}
+
+// Add{{ .MethodName | Singular }} adds a single item to the {{ .Name }} slice{{ .DeprecatedComment }}
+func (b *{{ $.BuilderType }}) Add{{ .MethodName | Singular }}({{ $item }} {{ .Ref.Elem.ParamType }}) *{{ $.BuilderType }} {
+	{{- range .EmbedInits }}
+	// Allocate embedded {{ .Path }} so the promoted field can be set
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
 	}
-	{{- if .IsNested }}
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }}[key] = val.Build().({{ $.ModelsPackage | base }}.{{ .ValType }})
+	{{- end }}
+	{{- if .Ref.HasBuilder }}
+	{{ $nested }} = append({{ $nested }}, {{ $item }})
 	{{- else }}
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }}[key] = val
+	{{ $field }} = append({{ $field }}, {{ $item }})
+	{{- if .IsOverride }}
+	b.promoted["{{ .Path }}"] = true
+	{{- end }}
 	{{- end }}
-	{{- else if .IsPointer }}
-	{{- if .IsNested }}
-	// Handle nested pointer
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}.BuildPtr()
+	return b
+}
+{{- end }}
+{{- end }}
+
+{{- range .Struct.Fields }}
+{{- if .IsMap }}
+{{- $param := .ParamName }}
+{{- $field := printf "%s.%s" $model .Path }}
+{{- $nested := printf "b.nested%s" .MethodName }}
+
+// Put{{ .MethodName | Singular }} sets a single entry of the {{ .Name }} map{{ .DeprecatedComment }}
+func (b *{{ $.BuilderType }}) Put{{ .MethodName | Singular }}(key {{ .Ref.Key.Type }}, val {{ .Ref.Elem.ParamType }}) *{{ $.BuilderType }} {
+	return b.With{{ .MethodName }}(key, val)
+}
+
+// Remove{{ .MethodName | Singular }} removes a single entry from the {{ .Name }} map{{ .DeprecatedComment }}
+func (b *{{ $.BuilderType }}) Remove{{ .MethodName | Singular }}(key {{ .Ref.Key.Type }}) *{{ $.BuilderType }} {
+	{{- if .Ref.HasBuilder }}
+	delete({{ $nested }}, key)
 	{{- else }}
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}
+	{{- range .EmbedInits }}
+	if {{ $model }}.{{ .Path }} == nil {
+		return b
+	}
+	{{- end }}
+	delete({{ $field }}, key)
+	{{- if .IsOverride }}
+	b.promoted["{{ .Path }}"] = true
 	{{- end }}
-	{{- else if .IsNested }}
-	builtValue := {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}.Build().(*{{ $.ModelsPackage | base }}.{{ .Type }})
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = *builtValue
+	{{- end }}
+	return b
+}
+
+// With{{ .MethodName }}Map replaces the {{ .Name }} map with a copy of the given entries{{ .DeprecatedComment }}
+func (b *{{ $.BuilderType }}) With{{ .MethodName }}Map({{ $param }} {{ .Ref.ParamType }}) *{{ $.BuilderType }} {
+	{{- range .EmbedInits }}
+	// Allocate embedded {{ .Path }} so the promoted field can be set
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	{{- if .Ref.HasBuilder }}
+	{{ $nested }} = make({{ .Ref.ParamType }}, len({{ $param }}))
+	for k, v := range {{ $param }} {
+		{{ $nested }}[k] = v
+	}
 	{{- else }}
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = {{ if eq (ToLowerFirst .Name) "type" }}value{{ else }}{{ ToParamName .Name }}{{ end }}
+	{{ $field }} = make({{ .Type }}, len({{ $param }}))
+	for k, v := range {{ $param }} {
+		{{ $field }}[k] = v
+	}
+	{{- if .IsOverride }}
+	b.promoted["{{ .Path }}"] = true
+	{{- end }}
 	{{- end }}
 	return b
 }
-{{ end }}
 
-{{- range .Struct.Fields }}
-{{- if and .IsSlice .IsNested }}
-// Add{{ .Name | Singular }} adds a single item to the {{ .Name }} slice
-func (b *{{ $.Struct.Name }}Builder) Add{{ .Name | Singular }}({{ ToParamName (.Name | Singular) }} *{{ .BuilderName }}) *{{ $.Struct.Name }}Builder {
-	// Ensure the slice is initialized
-	if b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} == nil {
-		b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = []*{{ $.ModelsPackage | base }}.{{ .ElementType }}{}
-	}
-	// Handle nested slice element
-	builtValue := {{ ToParamName (.Name | Singular) }}.Build().(*{{ $.ModelsPackage | base }}.{{ .ElementType }})
-	b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }} = append(b.{{ ToLowerFirst $.Struct.Name }}.{{ .Name }}, builtValue)
+// Merge{{ .MethodName }} adds the given entries to the {{ .Name }} map, overwriting existing keys{{ .DeprecatedComment }}
+func (b *{{ $.BuilderType }}) Merge{{ .MethodName }}({{ $param }} {{ .Ref.ParamType }}) *{{ $.BuilderType }} {
+	{{- range .EmbedInits }}
+	// Allocate embedded {{ .Path }} so the promoted field can be set
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	{{- if .Ref.HasBuilder }}
+	if {{ $nested }} == nil {
+		{{ $nested }} = make({{ .Ref.ParamType }}, len({{ $param }}))
+	}
+	for k, v := range {{ $param }} {
+		{{ $nested }}[k] = v
+	}
+	{{- else }}
+	if {{ $field }} == nil {
+		{{ $field }} = make({{ .Type }}, len({{ $param }}))
+	}
+	for k, v := range {{ $param }} {
+		{{ $field }}[k] = v
+	}
+	{{- if .IsOverride }}
+	b.promoted["{{ .Path }}"] = true
+	{{- end }}
+	{{- end }}
 	return b
 }
 {{- end }}
 {{- end }}
 
 // WithValidation adds a custom validation function
-func (b *{{ .Struct.Name }}Builder) WithValidation(validationFunc func(*{{ .ModelsPackage | base }}.{{ .Struct.Name }}) error) *{{ .Struct.Name }}Builder {
+func (b *{{ $.BuilderType }}) WithValidation(validationFunc func(*{{ $.ModelType }}) error) *{{ $.BuilderType }} {
 	b.validationFuncs = append(b.validationFuncs, validationFunc)
 	return b
 }
 
-// Build builds the {{ .Struct.Name }}
-func (b *{{ .Struct.Name }}Builder) Build() interface{} {
-	return b.{{ ToLowerFirst .Struct.Name }}
+// Build builds a new copy of the {{ .Struct.Name }} and returns it by value
+func (b *{{ $.BuilderType }}) Build() {{ $.ModelType }} {
+	{{ .Struct.VarName }}, _ := b.build(builder.NewGraph(false), false)
+	return *{{ .Struct.VarName }}
+}
+
+// BuildPtr builds a new copy of the {{ .Struct.Name }} and returns a pointer to it.
+// Later changes to the builder do not affect the returned {{ .Struct.Name }}.
+func (b *{{ $.BuilderType }}) BuildPtr() *{{ $.ModelType }} {
+	{{ .Struct.VarName }}, _ := b.build(builder.NewGraph(false), false)
+	return {{ .Struct.VarName }}
+}
+
+// BuildShared returns the {{ .Struct.Name }} the builder sets fields on, without
+// copying it. The result changes with the builder and is shared by every call.
+func (b *{{ $.BuilderType }}) BuildShared() *{{ $.ModelType }} {
+	{{ .Struct.VarName }}, _ := b.build(builder.NewGraph(true), false)
+	return {{ .Struct.VarName }}
 }
 
-// BuildPtr builds the {{ .Struct.Name }} and returns a pointer
-func (b *{{ .Struct.Name }}Builder) BuildPtr() *{{ .ModelsPackage | base }}.{{ .Struct.Name }} {
-	return b.{{ ToLowerFirst .Struct.Name }}
+// BuildAndValidate builds a new copy of the {{ .Struct.Name }} and validates it,
+// together with every nested builder
+func (b *{{ $.BuilderType }}) BuildAndValidate() (*{{ $.ModelType }}, error) {
+	return b.build(builder.NewGraph(false), true)
 }
 
-// BuildAndValidate builds the {{ .Struct.Name }} and validates it
-func (b *{{ .Struct.Name }}Builder) BuildAndValidate() (*{{ .ModelsPackage | base }}.{{ .Struct.Name }}, error) {
-	{{ ToLowerFirst .Struct.Name }} := b.{{ ToLowerFirst .Struct.Name }}
+// resolve builds the {{ .Struct.Name }} within g for a builder it is nested in
+func (b *{{ $.BuilderType }}) resolve(g *builder.Graph) *{{ $.ModelType }} {
+	{{ .Struct.VarName }}, _ := b.build(g, false)
+	return {{ .Struct.VarName }}
+}
+
+// build builds the {{ .Struct.Name }} within g, resolving the nested builders into it,
+// and, if validate is set, validates them and the result. A builder reached again
+// through nested builders returns the {{ .Struct.Name }} it has built already.
+func (b *{{ $.BuilderType }}) build(g *builder.Graph, validate bool) (*{{ $.ModelType }}, error) {
+	{{ .Struct.VarName }}, built := builder.Enter(g, b, b.model)
+	if built {
+		return {{ .Struct.VarName }}, nil
+	}
+	{{- if .Struct.HasOverrides }}
+	// Promoted fields set on the builder are applied again over embedded structs built
+	// by nested builders; embedded structs are copied before they are changed
+	p0 := *{{ .Struct.VarName }}
+	{{- end }}
+	{{- range .Struct.Fields }}
+	{{- if .Ref.HasBuilder }}
+	{{- $field := . }}
+	{{- $built := printf "%s.%s" $.Struct.VarName .Path }}
+	{{- $nested := printf "b.nested%s" .MethodName }}
+	{{- if .IsArray }}{{ $nested = printf "(*%s)" $nested }}{{ end }}
+	if b.nested{{ .MethodName }} != nil {
+		if validate {
+			{{ .Ref.Validate $nested .Name }}
+		}
+		{{- range .EmbedInits }}
+		{{- if $field.IsOverride }}
+		{{ $.Struct.VarName }}.{{ .Path }} = builder.Detach({{ $.Struct.VarName }}.{{ .Path }})
+		{{- else }}
+		if {{ $.Struct.VarName }}.{{ .Path }} == nil {
+			{{ $.Struct.VarName }}.{{ .Path }} = &{{ .Type }}{}
+		}
+		{{- end }}
+		{{- end }}
+		{{ .Ref.Resolve $built $nested }}
+		{{- range $.Struct.Overrides . }}
+		if b.promoted["{{ .Path }}"] {
+			{{- range .EmbedInits }}
+			{{ $.Struct.VarName }}.{{ .Path }} = builder.Detach({{ $.Struct.VarName }}.{{ .Path }})
+			{{- end }}
+			{{ $.Struct.VarName }}.{{ .Path }} = p0.{{ .Path }}
+		}
+		{{- end }}
+	}
+	{{- end }}
+	{{- end }}
+	if !validate {
+		return {{ .Struct.VarName }}, nil
+	}
+	{{- range .Struct.Fields }}
+	{{- if .Required }}
+
+	// Check required field {{ .Name }}
+	if {{ .ZeroCheck }} {
+		return nil, fmt.Errorf("required field {{ .Name }} is not set")
+	}
+	{{- end }}
+	{{- end }}
+	{{- range .Struct.Fields }}
+	{{- if and .Enum (not .EmbedInits) }}
+
+	// Check {{ .Name }} is one of its declared values
+	switch {{ $.Struct.VarName }}.{{ .Path }} {
+	case {{ range $i, $v := .EnumCases }}{{ if $i }}, {{ end }}{{ $v }}{{ end }}:
+	default:
+		return nil, fmt.Errorf("invalid value %v for field {{ .Name }}", {{ $.Struct.VarName }}.{{ .Path }})
+	}
+	{{- end }}
+	{{- end }}
 
 	// Run custom validation functions
 	for _, validationFunc := range b.validationFuncs {
-		if err := validationFunc({{ ToLowerFirst .Struct.Name }}); err != nil {
+		if err := validationFunc({{ .Struct.VarName }}); err != nil {
 			return nil, fmt.Errorf("custom validation failed: %w", err)
 		}
 	}
 
 	// Run model's Validate method if it exists
-	if v, ok := interface{}({{ ToLowerFirst .Struct.Name }}).(interface{ Validate() error }); ok {
+	if v, ok := interface{}({{ .Struct.VarName }}).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return {{ ToLowerFirst .Struct.Name }}, err
+			return {{ .Struct.VarName }}, err
 		}
 	}
 
-	return {{ ToLowerFirst .Struct.Name }}, nil
+	return {{ .Struct.VarName }}, nil
 }
 
 // MustBuild builds the {{ .Struct.Name }} and panics if validation fails
-func (b *{{ .Struct.Name }}Builder) MustBuild() *{{ .ModelsPackage | base }}.{{ .Struct.Name }} {
+func (b *{{ $.BuilderType }}) MustBuild() *{{ $.ModelType }} {
 	model, err := b.BuildAndValidate()
 	if err != nil {
 		panic(err)
@@ -146,14 +362,46 @@ func (b *{{ .Struct.Name }}Builder) MustBuild() *{{ .ModelsPackage | base }}.{{
 	return model
 }
 
-// Clone creates a deep copy of the builder
-func (b *{{ .Struct.Name }}Builder) Clone() *{{ .Struct.Name }}Builder {
-	cloned{{ .Struct.Name }} := *b.{{ ToLowerFirst .Struct.Name }}
-	return &{{ .Struct.Name }}Builder{
-		{{ ToLowerFirst .Struct.Name }}: &cloned{{ .Struct.Name }},
-		validationFuncs: append([]func(*{{ .ModelsPackage | base }}.{{ .Struct.Name }}) error{}, b.validationFuncs...),
-	}
+// Clone creates a deep copy of the builder as a builder.Builder, see CloneBuilder
+func (b *{{ $.BuilderType }}) Clone() builder.Builder[{{ $.ModelType }}] {
+	return b.CloneBuilder()
+}
+
+// CloneBuilder creates a deep copy of the builder: the {{ .Struct.Name }} built so far
+// and every nested builder are copied, keeping values shared within them shared
+func (b *{{ $.BuilderType }}) CloneBuilder() *{{ $.BuilderType }} {
+	return builder.Copy(builder.NewCopier(), b)
+}
+
+// CloneWith creates a deep copy of the builder with c, which copies builders and
+// values shared with other copies made by c only once
+func (b *{{ $.BuilderType }}) CloneWith(c *builder.Copier) any {
+	cloned := &{{ $.BuilderType }}{}
+	c.Record(b, cloned)
+	cloned.model = builder.Copy(c, b.model)
+	cloned.validationFuncs = append([]func(*{{ $.ModelType }}) error{}, b.validationFuncs...)
+	{{- if .Struct.HasOverrides }}
+	cloned.promoted = builder.Copy(c, b.promoted)
+	{{- end }}
+	{{- range .Struct.Fields }}
+	{{- if .Ref.HasBuilder }}
+	cloned.nested{{ .MethodName }} = builder.Copy(c, b.nested{{ .MethodName }})
+	{{- end }}
+	{{- end }}
+	return cloned
+}
+{{- if .Struct.CopyFields }}
+
+func init() {
+	// Copy the unexported fields of {{ .Struct.Name }} too when it is built or its builder cloned
+	builder.RegisterCopy(func(c *builder.Copier, dst, src *{{ $.ModelType }}) {
+		*dst = *src
+		{{- range .Struct.CopyFields }}
+		dst.{{ . }} = builder.Copy(c, src.{{ . }})
+		{{- end }}
+	})
 }
+{{- end }}
 `
 
 // RegistryTemplate contains the builder registry code
@@ -211,3 +459,314 @@ func Create(typeName string) (interface{}, error) {
 	return DefaultRegistry.Create(typeName)
 }
 `
+
+// OptionsTemplate is the template for generating functional options code
+const OptionsTemplate = `package {{ .PackageName }}
+{{- $model := .Struct.VarName }}
+{{- $option := printf "%sOption%s" .Struct.Name .Struct.TypeArgs }}
+
+import (
+{{ .ImportLines }})
+
+// {{ .Struct.Name }}Option configures a {{ .Struct.Name }} model
+type {{ .Struct.Name }}Option{{ .Struct.TypeParams }} func(*{{ $.ModelType }})
+
+{{- range .Struct.Fields }}
+{{- $param := .ParamName }}
+
+// {{ $.Struct.Name }}{{ .MethodName }} sets the {{ .Name }}{{ .DocComment }}
+func {{ $.Struct.Name }}{{ .MethodName }}{{ $.Struct.TypeParams }}({{ $param }} {{ .Type }}) {{ $option }} {
+	return func({{ $model }} *{{ $.ModelType }}) {
+		{{- range .EmbedInits }}
+		if {{ $model }}.{{ .Path }} == nil {
+			{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+		}
+		{{- end }}
+		{{ $model }}.{{ .Path }} = {{ $param }}
+	}
+}
+{{- end }}
+
+{{- range .Struct.Fields }}
+{{- $field := . }}
+{{- range .Enum }}
+
+// {{ $.Struct.Name }}{{ $field.MethodName }}{{ .Name }} sets the {{ $field.Name }} to {{ .Const }}{{ $field.DeprecatedComment }}
+func {{ $.Struct.Name }}{{ $field.MethodName }}{{ .Name }}{{ $.Struct.TypeParams }}() {{ $option }} {
+	return {{ $.Struct.Name }}{{ $field.MethodName }}{{ $.Struct.TypeArgs }}({{ .Value }})
+}
+{{- end }}
+{{- end }}
+
+// New{{ .Struct.Name }} creates a {{ .Struct.Name }}, applies the options and validates the result
+func New{{ .Struct.Name }}{{ .Struct.TypeParams }}(opts ...{{ $option }}) (*{{ $.ModelType }}, error) {
+	{{ $model }} := &{{ $.ModelType }}{
+		{{- range .Struct.Fields }}
+		{{- if not .IsPromoted }}
+		{{ .Name }}: {{ .ZeroValue }},
+		{{- end }}
+		{{- end }}
+	}
+	{{- range .Struct.Fields }}
+	{{- if .Default }}
+	{{- range .EmbedInits }}
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	{{ $model }}.{{ .Path }} = {{ .Default }}
+	{{- end }}
+	{{- end }}
+
+	for _, opt := range opts {
+		opt({{ $model }})
+	}
+	{{- range .Struct.Fields }}
+	{{- if .Required }}
+
+	// Check required field {{ .Name }}
+	if {{ .ZeroCheck }} {
+		return nil, fmt.Errorf("required field {{ .Name }} is not set")
+	}
+	{{- end }}
+	{{- end }}
+	{{- range .Struct.Fields }}
+	{{- if and .Enum (not .EmbedInits) }}
+
+	// Check {{ .Name }} is one of its declared values
+	switch {{ $model }}.{{ .Path }} {
+	case {{ range $i, $v := .EnumCases }}{{ if $i }}, {{ end }}{{ $v }}{{ end }}:
+	default:
+		return nil, fmt.Errorf("invalid value %v for field {{ .Name }}", {{ $model }}.{{ .Path }})
+	}
+	{{- end }}
+	{{- end }}
+
+	// Run model's Validate method if it exists
+	if v, ok := interface{}({{ $model }}).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return {{ $model }}, nil
+}
+`
+
+// StepBuilderTemplate is the template for generating step builder code. Each
+// required field is set by its own step, so Build is only reachable once all
+// of them have been set.
+const StepBuilderTemplate = `package {{ .PackageName }}
+{{- $model := "b.model" }}
+{{- $impl := printf "%sStepBuilder" (ToLowerFirst .Struct.Name) }}
+{{- $optional := printf "%sOptionalStep%s" .Struct.Name .Struct.TypeArgs }}
+{{- $steps := .Struct.Steps }}
+
+import (
+{{ .ImportLines }})
+
+{{- range $steps }}
+{{- $step := . }}
+{{- $param := .Field.ParamName }}
+
+// {{ $.Struct.Name }}{{ .Field.MethodName }}Step sets the required {{ .Field.Name }} of a {{ $.Struct.Name }}
+type {{ $.Struct.Name }}{{ .Field.MethodName }}Step{{ $.Struct.TypeParams }} interface {
+	{{- with .Field.DeprecationNotice }}
+	{{ . }}
+	{{- end }}
+	{{ .Field.MethodName }}({{ $param }} {{ .Field.Type }}) {{ .Next }}
+	{{- range .Field.Enum }}
+	{{- with $step.Field.DeprecationNotice }}
+	{{ . }}
+	{{- end }}
+	{{ $step.Field.MethodName }}{{ .Name }}() {{ $step.Next }}
+	{{- end }}
+}
+{{- end }}
+
+// {{ .Struct.Name }}OptionalStep sets the optional fields of a {{ .Struct.Name }} and builds it
+type {{ .Struct.Name }}OptionalStep{{ .Struct.TypeParams }} interface {
+	{{- range .Struct.Fields }}
+	{{- if not .Required }}
+	{{- $param := .ParamName }}
+	{{- $field := . }}
+	{{- with .DeprecationNotice }}
+	{{ . }}
+	{{- end }}
+	With{{ .MethodName }}({{ $param }} {{ .Type }}) {{ $optional }}
+	{{- range .Enum }}
+	{{- with $field.DeprecationNotice }}
+	{{ . }}
+	{{- end }}
+	With{{ $field.MethodName }}{{ .Name }}() {{ $optional }}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	WithValidation(validationFunc func(*{{ $.ModelType }}) error) {{ $optional }}
+	Build() {{ $.ModelType }}
+	BuildPtr() *{{ $.ModelType }}
+	BuildAndValidate() (*{{ $.ModelType }}, error)
+	MustBuild() *{{ $.ModelType }}
+}
+
+// {{ $impl }} implements every step of the {{ .Struct.Name }} step builder
+type {{ $impl }}{{ .Struct.TypeParams }} struct {
+	model *{{ $.ModelType }}
+	// Custom validation functions
+	validationFuncs []func(*{{ $.ModelType }}) error
+}
+
+// New{{ .Struct.Name }}Builder creates a step builder for {{ .Struct.Name }}, starting with its first required field
+func New{{ .Struct.Name }}Builder{{ .Struct.TypeParams }}() {{ if $steps }}{{ (index $steps 0).Interface }}{{ else }}{{ $optional }}{{ end }} {
+	b := &{{ $impl }}{{ .Struct.TypeArgs }}{
+		model: &{{ $.ModelType }}{
+			{{- range .Struct.Fields }}
+			{{- if not .IsPromoted }}
+			{{ .Name }}: {{ .ZeroValue }},
+			{{- end }}
+			{{- end }}
+		},
+		validationFuncs: []func(*{{ $.ModelType }}) error{},
+	}
+	{{- range .Struct.Fields }}
+	{{- if .Default }}
+	{{- range .EmbedInits }}
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	{{ $model }}.{{ .Path }} = {{ .Default }}
+	{{- end }}
+	{{- end }}
+	return b
+}
+
+{{- range $steps }}
+{{- $param := .Field.ParamName }}
+
+// {{ .Field.MethodName }} sets the required {{ .Field.Name }}{{ .Field.DocComment }}
+func (b *{{ $impl }}{{ $.Struct.TypeArgs }}) {{ .Field.MethodName }}({{ $param }} {{ .Field.Type }}) {{ .Next }} {
+	{{- range .Field.EmbedInits }}
+	// Allocate embedded {{ .Path }} so the promoted field can be set
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	{{ $model }}.{{ .Field.Path }} = {{ $param }}
+	return b
+}
+{{- $step := . }}
+{{- range .Field.Enum }}
+
+// {{ $step.Field.MethodName }}{{ .Name }} sets the required {{ $step.Field.Name }} to {{ .Const }}{{ $step.Field.DeprecatedComment }}
+func (b *{{ $impl }}{{ $.Struct.TypeArgs }}) {{ $step.Field.MethodName }}{{ .Name }}() {{ $step.Next }} {
+	return b.{{ $step.Field.MethodName }}({{ .Value }})
+}
+{{- end }}
+{{- end }}
+
+{{- range .Struct.Fields }}
+{{- if not .Required }}
+{{- $param := .ParamName }}
+
+// With{{ .MethodName }} sets the {{ .Name }}{{ .DocComment }}
+func (b *{{ $impl }}{{ $.Struct.TypeArgs }}) With{{ .MethodName }}({{ $param }} {{ .Type }}) {{ $optional }} {
+	{{- range .EmbedInits }}
+	// Allocate embedded {{ .Path }} so the promoted field can be set
+	if {{ $model }}.{{ .Path }} == nil {
+		{{ $model }}.{{ .Path }} = &{{ .Type }}{}
+	}
+	{{- end }}
+	{{ $model }}.{{ .Path }} = {{ $param }}
+	return b
+}
+{{- $field := . }}
+{{- range .Enum }}
+
+// With{{ $field.MethodName }}{{ .Name }} sets the {{ $field.Name }} to {{ .Const }}{{ $field.DeprecatedComment }}
+func (b *{{ $impl }}{{ $.Struct.TypeArgs }}) With{{ $field.MethodName }}{{ .Name }}() {{ $optional }} {
+	return b.With{{ $field.MethodName }}({{ .Value }})
+}
+{{- end }}
+{{- end }}
+{{- end }}
+
+// WithValidation adds a custom validation function
+func (b *{{ $impl }}{{ .Struct.TypeArgs }}) WithValidation(validationFunc func(*{{ $.ModelType }}) error) {{ $optional }} {
+	b.validationFuncs = append(b.validationFuncs, validationFunc)
+	return b
+}
+
+// Build builds a new copy of the {{ .Struct.Name }} and returns it by value
+func (b *{{ $impl }}{{ .Struct.TypeArgs }}) Build() {{ $.ModelType }} {
+	return *builder.DeepCopy(b.model)
+}
+
+// BuildPtr builds a new copy of the {{ .Struct.Name }} and returns a pointer to it.
+// Later changes to the builder do not affect the returned {{ .Struct.Name }}.
+func (b *{{ $impl }}{{ .Struct.TypeArgs }}) BuildPtr() *{{ $.ModelType }} {
+	return builder.DeepCopy(b.model)
+}
+
+// BuildAndValidate builds a new copy of the {{ .Struct.Name }} and validates it
+func (b *{{ $impl }}{{ .Struct.TypeArgs }}) BuildAndValidate() (*{{ $.ModelType }}, error) {
+	{{ .Struct.VarName }} := builder.DeepCopy(b.model)
+	{{- range .Struct.Fields }}
+	{{- if .Required }}
+
+	// Check required field {{ .Name }}
+	if {{ .ZeroCheck }} {
+		return nil, fmt.Errorf("required field {{ .Name }} is not set")
+	}
+	{{- end }}
+	{{- end }}
+	{{- range .Struct.Fields }}
+	{{- if and .Enum (not .EmbedInits) }}
+
+	// Check {{ .Name }} is one of its declared values
+	switch {{ $.Struct.VarName }}.{{ .Path }} {
+	case {{ range $i, $v := .EnumCases }}{{ if $i }}, {{ end }}{{ $v }}{{ end }}:
+	default:
+		return nil, fmt.Errorf("invalid value %v for field {{ .Name }}", {{ $.Struct.VarName }}.{{ .Path }})
+	}
+	{{- end }}
+	{{- end }}
+
+	// Run custom validation functions
+	for _, validationFunc := range b.validationFuncs {
+		if err := validationFunc({{ .Struct.VarName }}); err != nil {
+			return nil, fmt.Errorf("custom validation failed: %w", err)
+		}
+	}
+
+	// Run model's Validate method if it exists
+	if v, ok := interface{}({{ .Struct.VarName }}).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return {{ .Struct.VarName }}, err
+		}
+	}
+
+	return {{ .Struct.VarName }}, nil
+}
+
+// MustBuild builds the {{ .Struct.Name }} and panics if validation fails
+func (b *{{ $impl }}{{ .Struct.TypeArgs }}) MustBuild() *{{ $.ModelType }} {
+	model, err := b.BuildAndValidate()
+	if err != nil {
+		panic(err)
+	}
+	return model
+}
+{{- if .Struct.CopyFields }}
+
+func init() {
+	// Copy the unexported fields of {{ .Struct.Name }} too when it is built
+	builder.RegisterCopy(func(c *builder.Copier, dst, src *{{ $.ModelType }}) {
+		*dst = *src
+		{{- range .Struct.CopyFields }}
+		dst.{{ . }} = builder.Copy(c, src.{{ . }})
+		{{- end }}
+	})
+}
+{{- end }}
+`
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Template file names recognised by LoadTemplates
+const (
+	builderTemplateFile  = "builder.tmpl"
+	registryTemplateFile = "registry.tmpl"
+	utilTemplateFile     = "util.tmpl"
+)
+
+// Templates overrides the built-in templates. Empty fields use the defaults.
+//
+// Builder and Extra templates are executed once per struct with the same data
+// as the built-in templates (.PackageName, .ModelsPackage, .Struct, .ImportLines,
+// .ModelType and .BuilderType). Registry and Util templates are executed once
+// with .PackageName and .ModelsPackage and are prefixed with the package clause.
+type Templates struct {
+	Builder  string // Replaces the per-struct template of the selected style
+	Registry string // Replaces RegistryTemplate
+	Util     string // Replaces UtilTemplate
+
+	// Extra holds additional per-struct templates keyed by file suffix, so
+	// "_trace.go" generates person_trace.go for Person
+	Extra map[string]string
+}
+
+// LoadTemplates reads templates from dir. builder.tmpl, registry.tmpl and
+// util.tmpl override the built-in templates; any other <name>.tmpl is an extra
+// per-struct template generating <struct>_<name>.go.
+func LoadTemplates(dir string) (Templates, error) {
+	var templates Templates
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return templates, err
+	}
+	if len(paths) == 0 {
+		return templates, fmt.Errorf("no .tmpl files found in %s", dir)
+	}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return templates, fmt.Errorf("failed to read template %s: %v", path, err)
+		}
+
+		switch name := filepath.Base(path); name {
+		case builderTemplateFile:
+			templates.Builder = string(content)
+		case registryTemplateFile:
+			templates.Registry = string(content)
+		case utilTemplateFile:
+			templates.Util = string(content)
+		default:
+			if templates.Extra == nil {
+				templates.Extra = make(map[string]string)
+			}
+			templates.Extra["_"+strings.TrimSuffix(name, ".tmpl")+".go"] = string(content)
+		}
+	}
+
+	return templates, nil
+}
+
+// DefaultFuncs returns the functions available to every template
+func DefaultFuncs() template.FuncMap {
+	return template.FuncMap{
+		"ToLowerFirst": ToLowerFirst,
+		"ToUpperFirst": ToUpperFirst,
+		"ToParamName":  ToParamName,
+		"ToSnakeCase":  ToSnakeCase,
+		"replace":      strings.ReplaceAll,
+		"Singular":     ToSingular,
+		"base":         baseName,
+	}
+}
+
+// RegisterFunc makes fn available to templates under name, replacing any
+// built-in function of the same name
+func (g *Generator) RegisterFunc(name string, fn interface{}) {
+	if g.Options.Funcs == nil {
+		g.Options.Funcs = make(template.FuncMap)
+	}
+	g.Options.Funcs[name] = fn
+}
+
+// funcs returns the built-in template functions merged with the registered ones
+func (g *Generator) funcs() template.FuncMap {
+	funcs := DefaultFuncs()
+	for name, fn := range g.Options.Funcs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// render parses and executes a template
+func (g *Generator) render(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(g.funcs()).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", name, err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute %s template: %v", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// extraSuffixes returns the file suffixes of the extra templates in a stable order
+func (t Templates) extraSuffixes() []string {
+	suffixes := make([]string, 0, len(t.Extra))
+	for suffix := range t.Extra {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Strings(suffixes)
+	return suffixes
+}
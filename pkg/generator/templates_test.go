@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"builder.tmpl": "builder",
+		"util.tmpl":    "util",
+		"trace.tmpl":   "trace",
+		"README.md":    "ignored",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if templates.Builder != "builder" || templates.Util != "util" || templates.Registry != "" {
+		t.Errorf("Unexpected overrides: %+v", templates)
+	}
+	if len(templates.Extra) != 1 || templates.Extra["_trace.go"] != "trace" {
+		t.Errorf("Expected trace.tmpl as an extra template, got %v", templates.Extra)
+	}
+
+	if _, err := LoadTemplates(t.TempDir()); err == nil {
+		t.Errorf("Expected an error for a directory without templates")
+	}
+}
+
+func TestGenerateFileCustomTemplates(t *testing.T) {
+	gen := NewGenerator(Options{
+		PackageName:   "builders",
+		ModelsPackage: testModelsPackage,
+		Templates: Templates{
+			Builder: "package {{ .PackageName }}\n\n// {{ .BuilderType }} is custom\n",
+			Extra: map[string]string{
+				"_trace.go": "package {{ .PackageName }}\n\n// {{ Trace .Struct.Name }}\n",
+			},
+			Registry: "// Registry for {{ base .ModelsPackage }}\n",
+		},
+	})
+	gen.packages = testPackages
+	gen.RegisterFunc("Trace", func(name string) string { return "trace." + name })
+
+	files, err := gen.GenerateFile("testdata/models/models.go")
+	if err != nil {
+		t.Fatalf("GenerateFile failed: %v", err)
+	}
+
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.Name] = string(file.Content)
+	}
+	assertContains(t, contents["address_builder.go"], "// AddressBuilder is custom")
+	assertContains(t, contents["address_trace.go"], "// trace.Address")
+	assertContains(t, contents["page_trace.go"], "// trace.Page")
+
+	support, err := gen.SupportFiles()
+	if err != nil {
+		t.Fatalf("SupportFiles failed: %v", err)
+	}
+	if len(support) != 2 {
+		t.Fatalf("Expected util and registry files, got %d", len(support))
+	}
+	assertContains(t, string(support[0].Content), "package builders", "type BuilderUtil struct{}")
+	if registry := string(support[1].Content); registry != generatedHeader+"package builders\n\n// Registry for models\n" {
+		t.Errorf("Unexpected registry file:\n%s", registry)
+	}
+
+	gen.Options.Templates.Builder = "{{ .Missing"
+	if _, err := gen.GenerateFile("testdata/models/models.go"); err == nil || !strings.Contains(err.Error(), "failed to parse builder template") {
+		t.Errorf("Expected a template parse error, got %v", err)
+	}
+}
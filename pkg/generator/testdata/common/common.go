@@ -0,0 +1,8 @@
+package common
+
+// Meta is embedded by structs in other packages
+type Meta struct {
+	Version int
+	Owner   string
+	secret  string
+}
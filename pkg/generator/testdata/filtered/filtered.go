@@ -0,0 +1,45 @@
+package filtered
+
+// Order references structs that are excluded from generation
+type Order struct {
+	ID       string
+	Customer Customer
+	Request  *OrderRequest
+}
+
+// Customer places orders
+type Customer struct {
+	Name string
+}
+
+// OrderRequest is a DTO excluded by name
+type OrderRequest struct {
+	Items []string
+}
+
+// AuditRequest is generated despite its name
+//
+//builder:generate
+type AuditRequest struct {
+	Reason string
+}
+
+// Internal is never generated
+//
+//builder:skip
+type Internal struct {
+	Value int
+}
+
+type (
+	// Pair is never generated
+	//builder:skip
+	Pair struct {
+		Left, Right string
+	}
+
+	// Span is generated
+	Span struct {
+		From, To int
+	}
+)
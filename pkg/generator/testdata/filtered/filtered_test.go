@@ -0,0 +1,6 @@
+package filtered
+
+// Fixture is only used by tests
+type Fixture struct {
+	Order Order
+}
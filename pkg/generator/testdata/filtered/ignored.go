@@ -0,0 +1,8 @@
+//go:build ignore
+
+package filtered
+
+// Ignored is excluded by build constraints
+type Ignored struct {
+	Value string
+}
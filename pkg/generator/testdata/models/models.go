@@ -0,0 +1,168 @@
+package models
+
+import (
+	"time"
+
+	"github.com/adil-faiyaz98/go-builder-kit/pkg/generator/testdata/common"
+)
+
+// Status is a named basic type with declared values
+type Status string
+
+// Declared statuses
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+	StatusEnabled         = StatusActive
+	Suspended      Status = "suspended"
+)
+
+// PersonID is an alias for string
+type PersonID = string
+
+// Named is implemented by anything with a name
+type Named interface {
+	Name() string
+}
+
+// Address is a nested struct
+type Address struct {
+	Street string
+	City   string
+}
+
+// Person exercises the different kinds of field types
+type Person struct {
+	ID        PersonID
+	Status    Status
+	Address   Address
+	Home      *Address
+	CreatedAt time.Time
+	Owner     Named
+	Meta      any
+	Friends   []*Person
+}
+
+// Account exercises builder struct tags
+type Account struct {
+	ID       string            `builder:"required"`
+	Owner    string            `builder:"required,default=John Doe"`
+	Balance  float64           `builder:"default=100.5"`
+	Status   Status            `builder:"default=active"`
+	Tags     []string          `builder:"name=Keywords"`
+	Internal string            `builder:"-"`
+	Home     *Address          `builder:"style=value,required"`
+	Labels   map[string]string `json:"labels"`
+}
+
+// Number is a constraint satisfied by integer and float types
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Page is a generic pagination envelope
+type Page[T any] struct {
+	Items    []T
+	Total    int
+	Next     *Page[T]
+	Metadata map[string]T
+}
+
+// Stats is a generic struct with multiple constrained type parameters
+type Stats[K comparable, V Number] struct {
+	Key   K `builder:"required"`
+	Value V `builder:"required"`
+	Page  Page[V]
+}
+
+// Audit records who changed an entity
+type Audit struct {
+	UpdatedBy string
+	Owner     string
+}
+
+// BaseEntity is embedded by most models
+type BaseEntity struct {
+	ID        string `builder:"required"`
+	CreatedAt time.Time
+	*Audit
+}
+
+// Document embeds structs by value, by pointer and from another package
+type Document struct {
+	*BaseEntity
+	common.Meta
+	Title string
+	ID    int
+}
+
+// Comment embeds BaseEntity by pointer without shadowing its fields
+type Comment struct {
+	*BaseEntity
+	Text string
+}
+
+// Labelled embeds two structs that both declare Owner at the same depth
+type Labelled struct {
+	Audit
+	common.Meta
+}
+
+// Task is referenced from collections
+type Task struct {
+	Name string
+}
+
+// Board exercises value-element slices, arrays and nested collections
+type Board struct {
+	Addresses  []Address
+	Scores     [3]int
+	Corners    [4]*Address
+	Grid       [][]string
+	TaskGroups map[string][]*Task
+	Rows       []map[string]string
+	Lanes      [][]Task
+	Backlog    map[string]map[string]*Task
+}
+
+// Portfolio exercises maps with builder values
+type Portfolio struct {
+	Allocation map[string]float64
+	Holdings   map[string]Task
+	Watchlist  map[string]*Task
+}
+
+// Tags is a named slice type
+type Tags []string
+
+// Metadata is a named map type
+type Metadata map[string]string
+
+// Team is a named slice of models with builders
+type Team []*Person
+
+// Scores aliases a map type
+type Scores = map[string]int
+
+// Catalog exercises named collection types and aliases
+type Catalog struct {
+	Tags     Tags
+	Metadata Metadata
+	Team     Team
+	Scores   Scores
+}
+
+// Contact exercises field documentation
+type Contact struct {
+	// Email is the primary address.
+	//
+	// It is verified on sign-up.
+	Email string
+	Type  string // Home, Work, etc.
+	// Deprecated: use Email.
+	Mail string
+	// Fax numbers.
+	//
+	// Deprecated: nobody uses fax.
+	Fax []string
+}
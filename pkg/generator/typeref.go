@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// Shape describes how a type is composed
+type Shape int
+
+const (
+	// ShapeValue is a type that is not a pointer or collection
+	ShapeValue Shape = iota
+	// ShapePointer is a pointer type
+	ShapePointer
+	// ShapeSlice is a slice type
+	ShapeSlice
+	// ShapeArray is a fixed-size array type
+	ShapeArray
+	// ShapeMap is a map type
+	ShapeMap
+)
+
+// TypeRef is a recursive description of a field type. Pointers, slices, arrays
+// and maps have an Elem, maps also have a Key, and values are classified by Kind.
+type TypeRef struct {
+	Shape       Shape
+	Type        string   // Qualified type as written in generated code
+	Kind        TypeKind // Classification of values
+	Elem        *TypeRef // Element of pointers, slices, arrays and maps
+	Key         *TypeRef // Key of maps
+	Len         int64    // Length of arrays
+	BuilderName string   // Builder for structs in the models package
+}
+
+// newTypeRef builds the TypeRef for t. Named collection types get the shape of
+// their underlying type but keep their declared type, so setters accept a Tags
+// and Add helpers take its elements.
+func (r *typeResolver) newTypeRef(t types.Type) *TypeRef {
+	ref := &TypeRef{Type: r.typeString(t)}
+
+	switch u := structure(t).(type) {
+	case *types.Pointer:
+		ref.Shape = ShapePointer
+		ref.Elem = r.newTypeRef(u.Elem())
+	case *types.Slice:
+		ref.Shape = ShapeSlice
+		ref.Elem = r.newTypeRef(u.Elem())
+	case *types.Array:
+		ref.Shape = ShapeArray
+		ref.Len = u.Len()
+		ref.Elem = r.newTypeRef(u.Elem())
+	case *types.Map:
+		ref.Shape = ShapeMap
+		ref.Key = r.newTypeRef(u.Key())
+		ref.Elem = r.newTypeRef(u.Elem())
+	default:
+		ref.Shape = ShapeValue
+		ref.Kind = r.classify(t)
+		if ref.Kind == KindStruct {
+			ref.BuilderName = r.builderName(t)
+		}
+	}
+
+	return ref
+}
+
+// HasBuilder reports whether values of this type are set from nested builders
+func (t *TypeRef) HasBuilder() bool {
+	switch t.Shape {
+	case ShapeValue:
+		return t.BuilderName != ""
+	case ShapePointer:
+		return t.Elem.Shape == ShapeValue && t.Elem.BuilderName != ""
+	default:
+		return t.Elem.HasBuilder()
+	}
+}
+
+// withoutBuilders returns a copy of the TypeRef whose setters take plain values
+func (t *TypeRef) withoutBuilders() *TypeRef {
+	if t == nil {
+		return nil
+	}
+	plain := *t
+	plain.BuilderName = ""
+	plain.Elem = t.Elem.withoutBuilders()
+	return &plain
+}
+
+// ParamType returns the type a setter accepts for this type, replacing every
+// struct from the models package with its builder
+func (t *TypeRef) ParamType() string {
+	if !t.HasBuilder() {
+		return t.Type
+	}
+
+	switch t.Shape {
+	case ShapeValue:
+		return "*" + t.BuilderName
+	case ShapePointer:
+		return "*" + t.Elem.BuilderName
+	case ShapeSlice:
+		return "[]" + t.Elem.ParamType()
+	case ShapeArray:
+		return fmt.Sprintf("[%d]%s", t.Len, t.Elem.ParamType())
+	default:
+		return "map[" + t.Key.Type + "]" + t.Elem.ParamType()
+	}
+}
+
+// Resolve returns statements assigning src, of type ParamType, to dst, building
+// every builder within the build graph g. Collections of builders are converted
+// with loops, whose variables are numbered like those of convert so that they
+// cannot shadow the model variable, named after the struct.
+func (t *TypeRef) Resolve(dst, src string) string {
+	if !t.HasBuilder() || t.Shape == ShapeValue || t.Shape == ShapePointer {
+		return dst + " = " + t.convert(src, 0)
+	}
+
+	var lines []string
+	switch t.Shape {
+	case ShapeSlice:
+		lines = append(lines,
+			fmt.Sprintf("%s = make(%s, len(%s))", dst, t.Type, src),
+			fmt.Sprintf("for k0, v0 := range %s {", src),
+			fmt.Sprintf("\t%s[k0] = %s", dst, t.Elem.convert("v0", 1)),
+			"}")
+	case ShapeArray:
+		lines = append(lines,
+			fmt.Sprintf("for k0, v0 := range %s {", src),
+			fmt.Sprintf("\t%s[k0] = %s", dst, t.Elem.convert("v0", 1)),
+			"}")
+	case ShapeMap:
+		lines = append(lines,
+			fmt.Sprintf("%s = make(%s, len(%s))", dst, t.Type, src),
+			fmt.Sprintf("for k0, v0 := range %s {", src),
+			fmt.Sprintf("\t%s[k0] = %s", dst, t.Elem.convert("v0", 1)),
+			"}")
+	}
+	return strings.Join(lines, "\n\t")
+}
+
+// Validate returns statements building and validating every builder in src, of
+// type ParamType, within the build graph g. The first error is returned from the
+// enclosing function together with a nil model, prefixed with name.
+func (t *TypeRef) Validate(src, name string) string {
+	return t.validate(src, name, 0)
+}
+
+// validate returns the validation statements for src at the given nesting depth
+func (t *TypeRef) validate(src, name string, depth int) string {
+	switch t.Shape {
+	case ShapeValue, ShapePointer:
+		return fmt.Sprintf("if _, err := %s.build(g, true); err != nil {\n\treturn nil, fmt.Errorf(\"%s: %%w\", err)\n}", src, name)
+	}
+	v := fmt.Sprintf("v%d", depth)
+	return fmt.Sprintf("for _, %s := range %s {\n%s\n}", v, src, t.Elem.validate(v, name, depth+1))
+}
+
+// convert returns an expression converting src, of type ParamType, to this type
+// within the build graph g. depth keeps the names of variables in nested function
+// literals unique.
+func (t *TypeRef) convert(src string, depth int) string {
+	if !t.HasBuilder() {
+		return src
+	}
+
+	switch t.Shape {
+	case ShapeValue:
+		return "*" + src + ".resolve(g)"
+	case ShapePointer:
+		return src + ".resolve(g)"
+	}
+
+	in, out := fmt.Sprintf("in%d", depth), fmt.Sprintf("out%d", depth)
+	k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+	elem := t.Elem.convert(v, depth+1)
+
+	// Arrays are filled in place; slices and maps are allocated with the input's length
+	body := fmt.Sprintf("%s := make(%s, len(%s)); for %s, %s := range %s { %s[%s] = %s }", out, t.Type, in, k, v, in, out, k, elem)
+	if t.Shape == ShapeArray {
+		body = fmt.Sprintf("var %s %s; for %s, %s := range %s { %s[%s] = %s }", out, t.Type, k, v, in, out, k, elem)
+	}
+	return fmt.Sprintf("func(%s %s) %s { %s; return %s }(%s)", in, t.ParamType(), t.Type, body, out, src)
+}
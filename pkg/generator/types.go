@@ -0,0 +1,242 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// TypeKind classifies the named or basic type at the core of a field
+type TypeKind int
+
+const (
+	// KindBasic is a predeclared basic type (string, int, bool, ...)
+	KindBasic TypeKind = iota
+	// KindNamedBasic is a named type whose underlying type is basic (type Status string)
+	KindNamedBasic
+	// KindStruct is a struct declared in the models package, which gets a nested builder
+	KindStruct
+	// KindExternalStruct is a struct without a builder, from another package such
+	// as time.Time or excluded from generation
+	KindExternalStruct
+	// KindInterface is an interface type, including interface{} and any
+	KindInterface
+	// KindNamedOther is any other named type (named slices, maps, funcs, ...)
+	KindNamedOther
+	// KindTypeParam is a type parameter of a generic struct
+	KindTypeParam
+	// KindOther is any other unnamed type (funcs, channels, ...)
+	KindOther
+)
+
+// String returns the name of the kind
+func (k TypeKind) String() string {
+	switch k {
+	case KindBasic:
+		return "basic"
+	case KindNamedBasic:
+		return "named-basic"
+	case KindStruct:
+		return "struct"
+	case KindExternalStruct:
+		return "external-struct"
+	case KindInterface:
+		return "interface"
+	case KindNamedOther:
+		return "named"
+	case KindTypeParam:
+		return "type-param"
+	default:
+		return "other"
+	}
+}
+
+// typeResolver renders go/types types as Go source for the generated package and
+// records the imports those types need
+type typeResolver struct {
+	// pkg is the package whose structs are being processed
+	pkg *types.Package
+	// modelsName is the qualifier used for types declared in pkg
+	modelsName string
+	// imports collects import paths of other packages referenced by rendered types
+	imports map[string]bool
+	// qualifiers collects the package names qualifying rendered types
+	qualifiers map[string]bool
+	// builders holds the structs of pkg that get builders; nil means all of them
+	builders map[*types.TypeName]bool
+	// docs finds the comments of struct fields; nil leaves setters undocumented
+	docs *fieldDocs
+	// constBlocks maps the constants of pkg to their const declarations; nil
+	// detects no enums
+	constBlocks map[token.Pos]*ast.GenDecl
+}
+
+// newTypeResolver creates a typeResolver for the given models package
+func newTypeResolver(pkg *types.Package, modelsName string) *typeResolver {
+	return &typeResolver{
+		pkg:        pkg,
+		modelsName: modelsName,
+		imports:    make(map[string]bool),
+		qualifiers: make(map[string]bool),
+	}
+}
+
+// qualifier implements types.Qualifier for the generated package
+func (r *typeResolver) qualifier(p *types.Package) string {
+	if p == r.pkg {
+		r.qualifiers[r.modelsName] = true
+		return r.modelsName
+	}
+	r.imports[p.Path()] = true
+	r.qualifiers[p.Name()] = true
+	return p.Name()
+}
+
+// typeString renders t as it should appear in generated code
+func (r *typeResolver) typeString(t types.Type) string {
+	return types.TypeString(t, r.qualifier)
+}
+
+// structure returns t without aliases or, for named slice, array and map types
+// such as type Tags []string, their underlying collection type
+func structure(t types.Type) types.Type {
+	t = types.Unalias(t)
+	if named, ok := t.(*types.Named); ok {
+		switch u := named.Underlying().(type) {
+		case *types.Slice, *types.Array, *types.Map:
+			return u
+		}
+	}
+	return t
+}
+
+// classify returns the kind of t, looking through aliases
+func (r *typeResolver) classify(t types.Type) TypeKind {
+	switch t := types.Unalias(t).(type) {
+	case *types.Basic:
+		return KindBasic
+	case *types.Interface:
+		return KindInterface
+	case *types.TypeParam:
+		return KindTypeParam
+	case *types.Named:
+		switch t.Underlying().(type) {
+		case *types.Basic:
+			return KindNamedBasic
+		case *types.Struct:
+			if t.Obj().Pkg() == r.pkg && (r.builders == nil || r.builders[t.Obj()]) {
+				return KindStruct
+			}
+			return KindExternalStruct
+		case *types.Interface:
+			return KindInterface
+		default:
+			return KindNamedOther
+		}
+	default:
+		return KindOther
+	}
+}
+
+// zeroValue returns a Go expression for the zero value of t. Slices and maps
+// are initialised empty rather than nil, matching the builders' behaviour.
+func (r *typeResolver) zeroValue(t types.Type) string {
+	if _, ok := t.(*types.TypeParam); ok {
+		return "*new(" + r.typeString(t) + ")"
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		default:
+			return "nil"
+		}
+	case *types.Struct, *types.Array, *types.Slice, *types.Map:
+		return r.typeString(t) + "{}"
+	default:
+		return "nil"
+	}
+}
+
+// zeroCheck returns a boolean Go expression reporting whether expr, of type t,
+// holds its zero value
+func (r *typeResolver) zeroCheck(t types.Type, expr string) string {
+	if _, ok := t.(*types.TypeParam); ok {
+		r.imports["reflect"] = true
+		return "reflect.ValueOf(&" + expr + ").Elem().IsZero()"
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return expr + ` == ""`
+		case u.Info()&types.IsBoolean != 0:
+			return "!" + expr
+		case u.Info()&types.IsNumeric != 0:
+			return expr + " == 0"
+		}
+	case *types.Slice, *types.Map:
+		return "len(" + expr + ") == 0"
+	case *types.Pointer, *types.Interface, *types.Signature, *types.Chan:
+		return expr + " == nil"
+	}
+
+	if types.Comparable(t) {
+		return expr + " == (" + r.typeString(t) + "{})"
+	}
+	r.imports["reflect"] = true
+	return "reflect.ValueOf(&" + expr + ").Elem().IsZero()"
+}
+
+// defaultValue converts a default from a struct tag into a Go expression of type t.
+// String defaults are quoted unless they already are a string literal; anything
+// else is used verbatim.
+func (r *typeResolver) defaultValue(t types.Type, raw string) string {
+	if u, ok := t.Underlying().(*types.Basic); ok && u.Info()&types.IsString != 0 {
+		if _, err := strconv.Unquote(raw); err == nil {
+			return raw
+		}
+		return strconv.Quote(raw)
+	}
+	return raw
+}
+
+// builderName returns the name of the builder generated for a struct in the models
+// package, including type arguments for instantiated generic structs
+func (r *typeResolver) builderName(t types.Type) string {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return ""
+	}
+
+	name := named.Obj().Name() + "Builder"
+	if args := named.TypeArgs(); args.Len() > 0 {
+		var list []string
+		for i := 0; i < args.Len(); i++ {
+			list = append(list, r.typeString(args.At(i)))
+		}
+		name += "[" + strings.Join(list, ", ") + "]"
+	}
+	return name
+}
+
+// typeParamLists renders a type parameter list twice: as a declaration with
+// constraints ([K comparable, V any]) and as type arguments ([K, V])
+func (r *typeResolver) typeParamLists(params *types.TypeParamList) (string, string) {
+	var decls, names []string
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		decls = append(decls, param.Obj().Name()+" "+r.typeString(param.Constraint()))
+		names = append(names, param.Obj().Name())
+	}
+	return "[" + strings.Join(decls, ", ") + "]", "[" + strings.Join(names, ", ") + "]"
+}
@@ -0,0 +1,305 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// maxTypeErrors limits how many type errors are reported for generated code
+const maxTypeErrors = 10
+
+// generatedHeader marks generated files, so that later runs skip them as input
+const generatedHeader = "// Code generated by builder-gen. DO NOT EDIT.\n\n"
+
+// generatedComment matches the comment Go tools use to recognise generated files
+var generatedComment = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// formatSource formats generated code, adding the generated header unless the
+// template wrote its own, and reports syntax errors with the file name
+func formatSource(name string, code []byte) ([]byte, error) {
+	if !generatedComment.Match(code) {
+		code = append([]byte(generatedHeader), code...)
+	}
+
+	formatted, err := format.Source(code)
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %s:%v", name, err)
+	}
+	return formatted, nil
+}
+
+// WriteFiles type-checks the generated files and writes them to outputDir, removing
+// generated files they replace. Nothing is written if the generated code does not
+// compile.
+func (g *Generator) WriteFiles(outputDir string, files []GeneratedFile) error {
+	if err := g.Verify(outputDir, files); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		outputFile := filepath.Join(outputDir, file.Name)
+		if err := os.WriteFile(outputFile, file.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write builder file %s: %v", outputFile, err)
+		}
+
+		if g.Options.Verbose {
+			fmt.Printf("Generated builder file: %s\n", outputFile)
+		}
+	}
+
+	return removeReplaced(outputDir, files)
+}
+
+// Verify type-checks the generated files as one package, together with the Go
+// files already in outputDir that they do not replace, against the loaded models.
+// An empty outputDir checks the generated files on their own.
+// Errors are reported with the file and line of the generated code.
+func (g *Generator) Verify(outputDir string, files []GeneratedFile) error {
+	fset := token.NewFileSet()
+
+	var syntax []*ast.File
+	generated := make(map[string]bool)
+	for _, file := range files {
+		generated[file.Name] = true
+		f, err := parser.ParseFile(fset, filepath.Join(outputDir, file.Name), file.Content, 0)
+		if err != nil {
+			return fmt.Errorf("generated code is invalid: %v", err)
+		}
+		syntax = append(syntax, f)
+	}
+	if len(syntax) == 0 {
+		return nil
+	}
+
+	existing, err := g.existingFiles(fset, outputDir, generated)
+	if err != nil {
+		return err
+	}
+	syntax = append(syntax, existing...)
+
+	importer, err := g.newImporter(syntax)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	conf := types.Config{
+		Importer: importer,
+		Error: func(err error) {
+			errs = append(errs, err.Error())
+		},
+	}
+	conf.Check(syntax[0].Name.Name, fset, syntax, nil)
+
+	if len(errs) > 0 {
+		if len(errs) > maxTypeErrors {
+			errs = append(errs[:maxTypeErrors], fmt.Sprintf("and %d more errors", len(errs)-maxTypeErrors))
+		}
+		return fmt.Errorf("generated code does not compile:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// existingFiles parses the Go files of the output package that are not regenerated,
+// including in-memory sources in outputDir. Files excluded by build constraints are
+// left out, and so are generated files the result replaces under another name,
+// such as person_builder.go when person_builder_test.go is generated. Test files
+// are only included when the generated files are test files themselves.
+func (g *Generator) existingFiles(fset *token.FileSet, outputDir string, generated map[string]bool) ([]*ast.File, error) {
+	if outputDir == "" {
+		return nil, nil
+	}
+	outputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string][]byte)
+	entries, err := os.ReadDir(outputDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read output directory %s: %v", outputDir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			sources[filepath.Join(outputDir, entry.Name())] = nil
+		}
+	}
+	for path, content := range g.overlay {
+		if filepath.Dir(path) == outputDir {
+			sources[path] = content
+		}
+	}
+
+	var paths []string
+	for path := range sources {
+		name := filepath.Base(path)
+		if generated[name] || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		if !strings.HasSuffix(name, "_test.go") || g.Options.TestFiles {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	// Build constraints are matched against the sources read below, so that
+	// in-memory sources take precedence over files on disk
+	ctxt := build.Default
+	ctxt.OpenFile = func(path string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(sources[path])), nil
+	}
+
+	var files []*ast.File
+	for _, path := range paths {
+		if sources[path] == nil {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %v", path, err)
+			}
+			sources[path] = content
+		}
+		if bytes.HasPrefix(sources[path], []byte(generatedHeader)) && replaced(filepath.Base(path), generated) {
+			continue
+		}
+		match, err := ctxt.MatchFile(outputDir, filepath.Base(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		if !match {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, path, sources[path], 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		if f.Name.Name == g.packageName() {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// replaced reports whether a generated file named name is replaced by one of the
+// generated files, which happens when generation switches to or from test files
+func replaced(name string, generated map[string]bool) bool {
+	return generated[counterpart(name)]
+}
+
+// counterpart returns the name of the test file for name, or the other way round
+func counterpart(name string) string {
+	if base, ok := strings.CutSuffix(name, "_test.go"); ok {
+		return base + ".go"
+	}
+	return strings.TrimSuffix(name, ".go") + "_test.go"
+}
+
+// removeReplaced deletes the generated files in outputDir that files replace under
+// another name, so that the package does not declare everything twice
+func removeReplaced(outputDir string, files []GeneratedFile) error {
+	for _, file := range files {
+		path := filepath.Join(outputDir, counterpart(file.Name))
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		if !bytes.HasPrefix(content, []byte(generatedHeader)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove replaced file %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// packageImporter resolves imports of generated code to already loaded packages
+type packageImporter map[string]*types.Package
+
+// Import implements types.Importer
+func (imp packageImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := imp[path]; ok {
+		return pkg, nil
+	}
+	return nil, fmt.Errorf("package %s is not loaded", path)
+}
+
+// newImporter returns an importer for the imports of the given files. Packages
+// loaded for the models are preferred so that type identities match; any other
+// import is loaded and cached by import path.
+func (g *Generator) newImporter(files []*ast.File) (packageImporter, error) {
+	if g.packages == nil {
+		g.packages = make(map[string][]*packages.Package)
+	}
+
+	// Index packages loaded for the models first, then those loaded for imports
+	var keys []string
+	for key := range g.packages {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if filepath.IsAbs(keys[i]) != filepath.IsAbs(keys[j]) {
+			return filepath.IsAbs(keys[i])
+		}
+		return keys[i] < keys[j]
+	})
+
+	imp := make(packageImporter)
+	index := func(pkgs []*packages.Package) {
+		packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+			if _, ok := imp[pkg.PkgPath]; !ok && pkg.ID == pkg.PkgPath && pkg.Types != nil {
+				imp[pkg.PkgPath] = pkg.Types
+			}
+		})
+	}
+	for _, key := range keys {
+		index(g.packages[key])
+	}
+
+	var missing []string
+	for _, f := range files {
+		for _, spec := range f.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if _, ok := imp[path]; !ok && path != "C" {
+				missing = append(missing, path)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return imp, nil
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, missing...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load imports of generated code: %v", err)
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("failed to load package %s imported by generated code: %s", pkg.PkgPath, packageErrors(pkg))
+		}
+		g.packages[pkg.PkgPath] = []*packages.Package{pkg}
+	}
+	index(pkgs)
+
+	return imp, nil
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatSource(t *testing.T) {
+	formatted, err := formatSource("x.go", []byte("package x\nfunc f( ) {\nreturn}\n"))
+	if err != nil {
+		t.Fatalf("formatSource failed: %v", err)
+	}
+	if string(formatted) != generatedHeader+"package x\n\nfunc f() {\n\treturn\n}\n" {
+		t.Errorf("Unexpected formatting:\n%s", formatted)
+	}
+
+	// Templates can write their own generated header
+	own := "// Code generated by my-tool. DO NOT EDIT.\n\npackage x\n"
+	if formatted, err := formatSource("x.go", []byte(own)); err != nil || string(formatted) != own {
+		t.Errorf("Expected the template's header to be kept, got %v:\n%s", err, formatted)
+	}
+
+	// Lines are reported as in the written file, including the header
+	if _, err := formatSource("x.go", []byte("package x\nfunc f( {\n")); err == nil || !strings.Contains(err.Error(), "x.go:4:") {
+		t.Errorf("Expected a syntax error with file and line, got %v", err)
+	}
+}
+
+func TestProcessFileRefusesBrokenCode(t *testing.T) {
+	gen := NewGenerator(Options{
+		PackageName:   "builders",
+		ModelsPackage: testModelsPackage,
+		Templates: Templates{
+			Builder: "package {{ .PackageName }}\n\nimport (\n{{ .ImportLines }})\n\nvar _ = {{ .ModelType }}{Missing: 1}\n",
+		},
+	})
+	gen.packages = testPackages
+
+	outputDir := t.TempDir()
+	err := gen.ProcessFile("testdata/models/models.go", outputDir)
+	if err == nil {
+		t.Fatalf("Expected broken builders to be rejected")
+	}
+	assertContains(t, err.Error(), "generated code does not compile", filepath.Join(outputDir, "address_builder.go")+":")
+
+	entries, _ := os.ReadDir(outputDir)
+	if len(entries) != 0 {
+		t.Errorf("Expected nothing to be written, found %d files", len(entries))
+	}
+}
+
+func TestVerifyIncludesExistingFiles(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: testModelsPackage})
+	gen.packages = testPackages
+
+	outputDir := t.TempDir()
+	existing := "package builders\n\nfunc helper() int { return 1 }\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "helper.go"), []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Generated files can use declarations from the rest of the output package
+	if err := gen.Verify(outputDir, []GeneratedFile{{Name: "a.go", Content: []byte("package builders\n\nvar _ = helper()\n")}}); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+
+	// and must not redeclare them
+	err := gen.Verify(outputDir, []GeneratedFile{{Name: "a.go", Content: []byte("package builders\n\nfunc helper() {}\n")}})
+	if err == nil || !strings.Contains(err.Error(), "a.go:3:6") {
+		t.Errorf("Expected a redeclaration error with file and line, got %v", err)
+	}
+}
+
+func TestVerifySkipsExcludedAndReplacedFiles(t *testing.T) {
+	gen := NewGenerator(Options{PackageName: "builders", ModelsPackage: testModelsPackage, TestFiles: true})
+	gen.packages = testPackages
+
+	outputDir := t.TempDir()
+	existing := map[string]string{
+		// excluded by build constraints
+		"tools.go": "//go:build ignore\n\npackage builders\n\nfunc helper() {}\n",
+		// generated before builders were generated as test files
+		"person_builder.go": generatedHeader + "package builders\n\nfunc helper() {}\n",
+	}
+	for name, content := range existing {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := []GeneratedFile{{Name: "person_builder_test.go", Content: []byte("package builders\n\nfunc helper() {}\n")}}
+	if err := gen.Verify(outputDir, files); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+
+	// Files that are not replaced still count
+	gen.overlay = map[string][]byte{filepath.Join(outputDir, "helper.go"): []byte("package builders\n\nfunc helper() {}\n")}
+	if err := gen.Verify(outputDir, files); err == nil {
+		t.Error("Expected a redeclaration error for helper.go")
+	}
+}
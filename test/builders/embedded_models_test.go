@@ -0,0 +1,38 @@
+package builders
+
+import "testing"
+
+func TestPromotedFieldsOverrideEmbeddedBuilders(t *testing.T) {
+	record := NewRecordBuilder().WithID("rec-1").WithVersion(1).WithUpdatedBy("alice")
+
+	// Promoted fields apply whether they are set before or after the embedded builder
+	doc := NewDocumentBuilder().
+		WithVersion(2).
+		WithRecord(record).
+		AddReviewer("bob").
+		WithTitle("Spec").
+		BuildPtr()
+
+	if doc.ID != "rec-1" || doc.UpdatedBy != "alice" {
+		t.Errorf("Expected the fields of the Record builder, got %+v", doc.Record)
+	}
+	if doc.Version != 2 || len(doc.Reviewers) != 1 || doc.Reviewers[0] != "bob" {
+		t.Errorf("Expected promoted fields set on the Document builder to override, got %+v %+v", doc.Record, doc.Audit)
+	}
+
+	// The Record builder is left alone
+	built := record.BuildPtr()
+	if built.Version != 1 || len(built.Reviewers) != 0 {
+		t.Errorf("Expected the Record builder to be unchanged, got %+v %+v", built, built.Audit)
+	}
+
+	// A nested builder for a deeper embedded struct is overridden as well
+	doc = NewDocumentBuilder().
+		WithUpdatedBy("carol").
+		WithRecord(record).
+		WithAudit(NewAuditBuilder().WithUpdatedBy("dave").AddReviewer("erin")).
+		BuildPtr()
+	if doc.ID != "rec-1" || doc.UpdatedBy != "carol" || len(doc.Reviewers) != 1 {
+		t.Errorf("Expected UpdatedBy to override both nested builders, got %+v %+v", doc.Record, doc.Audit)
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+// Audit records who last changed a record
+type Audit struct {
+	UpdatedBy string
+	Reviewers []string
+}
+
+// Record holds the fields shared by tracked models
+type Record struct {
+	ID      string
+	Version int
+	*Audit
+}
+
+// Document embeds a Record, whose fields are promoted
+type Document struct {
+	*Record
+	Title string
+}